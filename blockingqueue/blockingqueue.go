@@ -0,0 +1,232 @@
+package blockingqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/idsulik/go-collections/v3/ringbuffer"
+)
+
+// ErrClosed is returned when putting into a closed queue, or taking from a closed and drained queue
+var ErrClosed = errors.New("blockingqueue: queue is closed")
+
+// errDone reports that the done channel was closed while waiting
+var errDone = errors.New("blockingqueue: done")
+
+// closedChan is used as the done channel of operations that must not wait
+var closedChan = func() chan struct{} {
+	c := make(chan struct{})
+	close(c)
+	return c
+}()
+
+// BlockingQueue is a bounded FIFO queue that is safe for concurrent use. Put and Take wait for
+// space or items until their context is done, and Offer and Poll wait up to a timeout.
+// The non-blocking methods implement collections.Queue.
+type BlockingQueue[T any] struct {
+	mu     sync.Mutex
+	items  *ringbuffer.RingBuffer[T]
+	closed bool
+
+	// notEmpty and notFull are closed and replaced to wake up waiting takers and putters
+	notEmpty chan struct{}
+	notFull  chan struct{}
+	takers   int // number of goroutines waiting on notEmpty
+	putters  int // number of goroutines waiting on notFull
+}
+
+// New creates a new BlockingQueue that holds at most capacity items.
+// If the capacity is less than 1, it is set to 1.
+func New[T any](capacity int) *BlockingQueue[T] {
+	return &BlockingQueue[T]{
+		items:    ringbuffer.New[T](capacity),
+		notEmpty: make(chan struct{}),
+		notFull:  make(chan struct{}),
+	}
+}
+
+// Put adds an item to the back of the queue, waiting for space if it is full.
+// It returns ctx.Err() if ctx is done before there is space, or ErrClosed if the queue is closed.
+// If there is space, Put succeeds even if ctx is already done.
+func (q *BlockingQueue[T]) Put(ctx context.Context, item T) error {
+	err := q.put(ctx.Done(), item)
+	if err == errDone {
+		return ctx.Err()
+	}
+	return err
+}
+
+// Take removes and returns the item at the front of the queue, waiting for one if it is empty.
+// It returns ctx.Err() if ctx is done before an item is available, or ErrClosed once the queue is
+// closed and drained. If there is an item, Take succeeds even if ctx is already done.
+func (q *BlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	item, err := q.take(ctx.Done())
+	if err == errDone {
+		return item, ctx.Err()
+	}
+	return item, err
+}
+
+// Offer adds an item to the back of the queue, waiting up to timeout for space if it is full.
+// It returns false if the item was not added because the timeout expired or the queue is closed.
+// A timeout of zero or less does not wait.
+func (q *BlockingQueue[T]) Offer(item T, timeout time.Duration) bool {
+	if timeout <= 0 {
+		return q.put(closedChan, item) == nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return q.put(ctx.Done(), item) == nil
+}
+
+// Poll removes and returns the item at the front of the queue, waiting up to timeout for one if it
+// is empty. It returns false if no item became available. A timeout of zero or less does not wait.
+func (q *BlockingQueue[T]) Poll(timeout time.Duration) (T, bool) {
+	if timeout <= 0 {
+		item, err := q.take(closedChan)
+		return item, err == nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	item, err := q.take(ctx.Done())
+	return item, err == nil
+}
+
+// Enqueue adds an item to the back of the queue without waiting.
+// The item is dropped if the queue is full or closed; use Offer to find out whether it was added.
+func (q *BlockingQueue[T]) Enqueue(item T) {
+	q.Offer(item, 0)
+}
+
+// Dequeue removes and returns the item at the front of the queue without waiting.
+// Returns false if the queue is empty.
+func (q *BlockingQueue[T]) Dequeue() (T, bool) {
+	return q.Poll(0)
+}
+
+// Peek returns the item at the front of the queue without removing it.
+// Returns false if the queue is empty.
+func (q *BlockingQueue[T]) Peek() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Peek()
+}
+
+// Close closes the queue and wakes up all waiting goroutines. Later puts fail with ErrClosed, while
+// takes return the remaining items and then ErrClosed. Closing a closed queue has no effect.
+func (q *BlockingQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	close(q.notEmpty)
+	close(q.notFull)
+}
+
+// IsClosed returns true if the queue has been closed.
+func (q *BlockingQueue[T]) IsClosed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.closed
+}
+
+// Len returns the number of items currently in the queue.
+func (q *BlockingQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Len()
+}
+
+// Cap returns the maximum number of items the queue can hold.
+func (q *BlockingQueue[T]) Cap() int {
+	return q.items.Cap()
+}
+
+// IsEmpty checks if the queue is empty.
+func (q *BlockingQueue[T]) IsEmpty() bool {
+	return q.Len() == 0
+}
+
+// Clear removes all items from the queue and wakes up goroutines waiting for space.
+func (q *BlockingQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for !q.items.IsEmpty() {
+		q.items.Read()
+	}
+	q.signalNotFull()
+}
+
+// put adds an item, waiting for space until done is closed
+func (q *BlockingQueue[T]) put(done <-chan struct{}, item T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for !q.closed && q.items.IsFull() {
+		q.putters++
+		signaled := q.wait(done, q.notFull)
+		q.putters--
+		if !signaled {
+			return errDone
+		}
+	}
+	if q.closed {
+		return ErrClosed
+	}
+	q.items.Write(item)
+	q.signalNotEmpty()
+	return nil
+}
+
+// take removes the front item, waiting for one until done is closed
+func (q *BlockingQueue[T]) take(done <-chan struct{}) (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for !q.closed && q.items.IsEmpty() {
+		q.takers++
+		signaled := q.wait(done, q.notEmpty)
+		q.takers--
+		if !signaled {
+			var zero T
+			return zero, errDone
+		}
+	}
+	item, ok := q.items.Read()
+	if !ok {
+		return item, ErrClosed
+	}
+	q.signalNotFull()
+	return item, nil
+}
+
+// wait releases mu until signal or done is closed, and returns true in the former case.
+// It must be called with mu held.
+func (q *BlockingQueue[T]) wait(done <-chan struct{}, signal chan struct{}) bool {
+	q.mu.Unlock()
+	defer q.mu.Lock()
+	select {
+	case <-signal:
+		return true
+	case <-done:
+		return false
+	}
+}
+
+func (q *BlockingQueue[T]) signalNotEmpty() {
+	if q.takers > 0 && !q.closed {
+		close(q.notEmpty)
+		q.notEmpty = make(chan struct{})
+	}
+}
+
+func (q *BlockingQueue[T]) signalNotFull() {
+	if q.putters > 0 && !q.closed {
+		close(q.notFull)
+		q.notFull = make(chan struct{})
+	}
+}
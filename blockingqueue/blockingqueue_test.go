@@ -0,0 +1,334 @@
+package blockingqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/idsulik/go-collections/v3/collections"
+)
+
+var _ collections.Queue[int] = (*BlockingQueue[int])(nil)
+
+func TestBlockingQueue(t *testing.T) {
+	t.Run(
+		"New queue creation", func(t *testing.T) {
+			q := New[int](3)
+			if q.Cap() != 3 || !q.IsEmpty() {
+				t.Errorf("Expected empty queue with capacity 3, got Len %d and Cap %d", q.Len(), q.Cap())
+			}
+			if New[int](0).Cap() != 1 {
+				t.Error("Non-positive capacity should fall back to 1")
+			}
+		},
+	)
+
+	t.Run(
+		"Non-blocking methods", func(t *testing.T) {
+			q := New[int](2)
+			q.Enqueue(1)
+			q.Enqueue(2)
+			q.Enqueue(3) // dropped, the queue is full
+			if q.Len() != 2 {
+				t.Errorf("Expected length 2, got %d", q.Len())
+			}
+			if item, ok := q.Peek(); !ok || item != 1 {
+				t.Errorf("Peek() = %d, %v; want 1, true", item, ok)
+			}
+			for _, expected := range []int{1, 2} {
+				if item, ok := q.Dequeue(); !ok || item != expected {
+					t.Errorf("Dequeue() = %d, %v; want %d, true", item, ok, expected)
+				}
+			}
+			if _, ok := q.Dequeue(); ok {
+				t.Error("Dequeue should fail on an empty queue")
+			}
+		},
+	)
+
+	t.Run(
+		"Put and Take", func(t *testing.T) {
+			q := New[string](2)
+			ctx := context.Background()
+			if err := q.Put(ctx, "a"); err != nil {
+				t.Fatalf("Put() error: %v", err)
+			}
+			item, err := q.Take(ctx)
+			if err != nil || item != "a" {
+				t.Errorf("Take() = %q, %v; want \"a\", nil", item, err)
+			}
+		},
+	)
+
+	t.Run(
+		"Clear", func(t *testing.T) {
+			q := New[int](2)
+			q.Enqueue(1)
+			q.Enqueue(2)
+			q.Clear()
+			if !q.IsEmpty() {
+				t.Error("Queue should be empty after Clear")
+			}
+			if !q.Offer(3, 0) {
+				t.Error("Offer should succeed after Clear")
+			}
+		},
+	)
+}
+
+func TestBlockingQueue_Context(t *testing.T) {
+	t.Run(
+		"Take on empty queue waits until canceled", func(t *testing.T) {
+			q := New[int](1)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			if _, err := q.Take(ctx); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Take() = %v, want context.DeadlineExceeded", err)
+			}
+		},
+	)
+
+	t.Run(
+		"Put on full queue waits until canceled", func(t *testing.T) {
+			q := New[int](1)
+			q.Enqueue(1)
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			if err := q.Put(ctx, 2); !errors.Is(err, context.Canceled) {
+				t.Errorf("Put() = %v, want context.Canceled", err)
+			}
+			if q.Len() != 1 {
+				t.Errorf("Expected length 1, got %d", q.Len())
+			}
+		},
+	)
+
+	t.Run(
+		"Done context does not fail when no wait is needed", func(t *testing.T) {
+			q := New[int](1)
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			if err := q.Put(ctx, 1); err != nil {
+				t.Errorf("Put() = %v, want nil", err)
+			}
+			if item, err := q.Take(ctx); err != nil || item != 1 {
+				t.Errorf("Take() = %d, %v; want 1, nil", item, err)
+			}
+		},
+	)
+
+	t.Run(
+		"Take is woken up by Put", func(t *testing.T) {
+			q := New[int](1)
+			result := make(chan int)
+			go func() {
+				item, _ := q.Take(context.Background())
+				result <- item
+			}()
+
+			time.Sleep(5 * time.Millisecond)
+			q.Put(context.Background(), 42)
+			if item := <-result; item != 42 {
+				t.Errorf("Take() = %d, want 42", item)
+			}
+		},
+	)
+
+	t.Run(
+		"Put is woken up by Take", func(t *testing.T) {
+			q := New[int](1)
+			q.Enqueue(1)
+			done := make(chan error)
+			go func() {
+				done <- q.Put(context.Background(), 2)
+			}()
+
+			time.Sleep(5 * time.Millisecond)
+			q.Take(context.Background())
+			if err := <-done; err != nil {
+				t.Errorf("Put() = %v, want nil", err)
+			}
+			if item, _ := q.Peek(); item != 2 {
+				t.Errorf("Expected 2 at the front, got %d", item)
+			}
+		},
+	)
+}
+
+func TestBlockingQueue_Timeouts(t *testing.T) {
+	q := New[int](1)
+
+	start := time.Now()
+	if _, ok := q.Poll(20 * time.Millisecond); ok {
+		t.Error("Poll should fail on an empty queue")
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("Poll returned after %v, want at least 20ms", elapsed)
+	}
+
+	if !q.Offer(1, time.Second) {
+		t.Error("Offer should succeed on an empty queue")
+	}
+	if q.Offer(2, 10*time.Millisecond) {
+		t.Error("Offer should fail on a full queue")
+	}
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		q.Dequeue()
+	}()
+	if !q.Offer(3, time.Second) {
+		t.Error("Offer should succeed once space is made")
+	}
+	if item, ok := q.Poll(time.Second); !ok || item != 3 {
+		t.Errorf("Poll() = %d, %v; want 3, true", item, ok)
+	}
+}
+
+func TestBlockingQueue_Close(t *testing.T) {
+	t.Run(
+		"Close wakes up waiters", func(t *testing.T) {
+			empty := New[int](1)
+			full := New[int](1)
+			full.Enqueue(1)
+
+			var wg sync.WaitGroup
+			errs := make(chan error, 4)
+			for i := 0; i < 2; i++ {
+				wg.Add(2)
+				go func() {
+					defer wg.Done()
+					_, err := empty.Take(context.Background())
+					errs <- err
+				}()
+				go func() {
+					defer wg.Done()
+					errs <- full.Put(context.Background(), 2)
+				}()
+			}
+
+			time.Sleep(5 * time.Millisecond)
+			empty.Close()
+			full.Close()
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				if !errors.Is(err, ErrClosed) {
+					t.Errorf("Expected ErrClosed, got %v", err)
+				}
+			}
+		},
+	)
+
+	t.Run(
+		"Remaining items are drained", func(t *testing.T) {
+			q := New[int](3)
+			q.Enqueue(1)
+			q.Enqueue(2)
+			q.Close()
+			q.Close()
+
+			if !q.IsClosed() {
+				t.Error("Queue should be closed")
+			}
+			if err := q.Put(context.Background(), 3); !errors.Is(err, ErrClosed) {
+				t.Errorf("Put() = %v, want ErrClosed", err)
+			}
+			if q.Offer(3, time.Second) {
+				t.Error("Offer should fail on a closed queue")
+			}
+			for _, expected := range []int{1, 2} {
+				if item, err := q.Take(context.Background()); err != nil || item != expected {
+					t.Errorf("Take() = %d, %v; want %d, nil", item, err, expected)
+				}
+			}
+			if _, err := q.Take(context.Background()); !errors.Is(err, ErrClosed) {
+				t.Errorf("Take() = %v, want ErrClosed", err)
+			}
+		},
+	)
+}
+
+func TestBlockingQueue_WorkerPool(t *testing.T) {
+	const (
+		producers = 4
+		workers   = 4
+		perWriter = 1000
+	)
+	q := New[int](8)
+	ctx := context.Background()
+
+	var producersWg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		producersWg.Add(1)
+		go func(p int) {
+			defer producersWg.Done()
+			for i := 0; i < perWriter; i++ {
+				if err := q.Put(ctx, p*perWriter+i); err != nil {
+					t.Errorf("Put() error: %v", err)
+				}
+			}
+		}(p)
+	}
+
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+	var workersWg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		workersWg.Add(1)
+		go func() {
+			defer workersWg.Done()
+			for {
+				item, err := q.Take(ctx)
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				seen[item] = true
+				mu.Unlock()
+			}
+		}()
+	}
+
+	producersWg.Wait()
+	q.Close()
+	workersWg.Wait()
+	if len(seen) != producers*perWriter {
+		t.Errorf("Expected %d distinct items, got %d", producers*perWriter, len(seen))
+	}
+}
+
+func BenchmarkBlockingQueue(b *testing.B) {
+	b.Run(
+		"PutTake", func(b *testing.B) {
+			q := New[int](1024)
+			ctx := context.Background()
+			b.ReportAllocs()
+			go func() {
+				for i := 0; i < b.N; i++ {
+					q.Put(ctx, i)
+				}
+			}()
+			for i := 0; i < b.N; i++ {
+				q.Take(ctx)
+			}
+		},
+	)
+
+	b.Run(
+		"Channel", func(b *testing.B) {
+			ch := make(chan int, 1024)
+			b.ReportAllocs()
+			go func() {
+				for i := 0; i < b.N; i++ {
+					ch <- i
+				}
+			}()
+			for i := 0; i < b.N; i++ {
+				<-ch
+			}
+		},
+	)
+}
@@ -1,82 +1,108 @@
 package bloomfilter
 
 import (
-	"crypto/sha256"
-	"encoding/binary"
-	"fmt"
-	"hash"
-	"hash/fnv"
 	"math"
 )
 
 type BloomFilter[T any] struct {
-	bits    []bool
+	bits    []uint64
 	numBits uint
 	numHash uint
 	count   uint
-	hasher  hash.Hash
+	hash    Hasher[T]
 }
 
-func NewBloomFilter[T any](expectedItems uint, falsePositiveProb float64) *BloomFilter[T] {
-	if expectedItems == 0 {
-		expectedItems = 1
+// Option is a function that configures a filter.
+type Option[T any] func(*config[T])
+
+type config[T any] struct {
+	hash Hasher[T]
+}
+
+func newConfig[T any](opts []Option[T]) config[T] {
+	cfg := config[T]{
+		hash: DefaultHasher[T](),
 	}
-	if falsePositiveProb <= 0 {
-		falsePositiveProb = 0.01
+	for _, opt := range opts {
+		opt(&cfg)
 	}
+	return cfg
+}
 
-	numBits := uint(math.Ceil(-float64(expectedItems) * math.Log(falsePositiveProb) / math.Pow(math.Log(2), 2)))
-	numHash := uint(math.Ceil(float64(numBits) / float64(expectedItems) * math.Log(2)))
-
-	return &BloomFilter[T]{
-		bits:    make([]bool, numBits),
-		numBits: numBits,
-		numHash: numHash,
-		hasher:  fnv.New64a(), // Using fnv.New64a() for better distribution
+// WithHasher sets the function used to hash items.
+func WithHasher[T any](hasher Hasher[T]) Option[T] {
+	return func(cfg *config[T]) {
+		cfg.hash = hasher
 	}
 }
 
-// hashToUint converts a hash sum to uint
-func hashToUint(sum []byte) uint {
-	// Convert the first 8 bytes of the hash to uint64, then to uint
-	if len(sum) < 8 {
-		panic("Hash sum too short")
+// WithEncoder hashes items from the bytes produced by enc, which avoids the fmt-based
+// fallback of the default hasher for custom types.
+func WithEncoder[T any](enc Encoder[T]) Option[T] {
+	return func(cfg *config[T]) {
+		cfg.hash = EncoderHasher(enc)
 	}
-	return uint(binary.BigEndian.Uint64(sum[:8]))
 }
 
-// getLocations generates multiple hash locations for an item
-func (bf *BloomFilter[T]) getLocations(item T) []uint {
-	locations := make([]uint, bf.numHash)
-	itemStr := fmt.Sprintf("%v", item)
+func NewBloomFilter[T any](expectedItems uint, falsePositiveProb float64, opts ...Option[T]) *BloomFilter[T] {
+	cfg := newConfig(opts)
+	return newBloomFilter(expectedItems, falsePositiveProb, cfg.hash)
+}
+
+func newBloomFilter[T any](expectedItems uint, falsePositiveProb float64, hash Hasher[T]) *BloomFilter[T] {
+	numBits, numHash := optimalParameters(expectedItems, falsePositiveProb)
+	return &BloomFilter[T]{
+		bits:    make([]uint64, (numBits+63)/64),
+		numBits: numBits,
+		numHash: numHash,
+		hash:    hash,
+	}
+}
 
-	// Calculate SHA-256 hash as a base for location generation
-	hash := sha256.Sum256([]byte(itemStr))
-	h1 := hashToUint(hash[:8])   // Use first 8 bytes for h1
-	h2 := hashToUint(hash[8:16]) // Use next 8 bytes for h2
+// maxHashFunctions bounds the number of hash functions. More would only help for false positive
+// probabilities below 2^-64, which the 64-bit hashes cannot deliver anyway.
+const maxHashFunctions = 64
 
-	// Generate all hash values using the formula: h1 + i*h2
-	for i := uint(0); i < bf.numHash; i++ {
-		locations[i] = (h1 + i*h2) % bf.numBits
+// optimalParameters returns the number of bits and hash functions that reach the false positive
+// probability with the expected number of items.
+func optimalParameters(expectedItems uint, falsePositiveProb float64) (numBits, numHash uint) {
+	if expectedItems == 0 {
+		expectedItems = 1
+	}
+	if falsePositiveProb <= 0 {
+		falsePositiveProb = 0.01
 	}
 
-	return locations
+	numBits = uint(math.Ceil(-float64(expectedItems) * math.Log(falsePositiveProb) / math.Pow(math.Log(2), 2)))
+	numHash = uint(math.Ceil(float64(numBits) / float64(expectedItems) * math.Log(2)))
+	if numHash > maxHashFunctions {
+		numHash = maxHashFunctions
+	}
+	return numBits, numHash
 }
 
 // Add inserts an item into the Bloom Filter.
 func (bf *BloomFilter[T]) Add(item T) {
-	locations := bf.getLocations(item)
-	for _, loc := range locations {
-		bf.bits[loc] = true
+	h1, h2 := bf.hash(item)
+	numBits := uint64(bf.numBits)
+	for i := uint64(0); i < uint64(bf.numHash); i++ {
+		loc := (h1 + i*h2) % numBits
+		bf.bits[loc/64] |= 1 << (loc % 64)
 	}
 	bf.count++
 }
 
 // Contains tests whether an item might be in the set.
 func (bf *BloomFilter[T]) Contains(item T) bool {
-	locations := bf.getLocations(item)
-	for _, loc := range locations {
-		if !bf.bits[loc] {
+	h1, h2 := bf.hash(item)
+	return bf.containsHash(h1, h2)
+}
+
+func (bf *BloomFilter[T]) containsHash(h1, h2 uint64) bool {
+	numBits := uint64(bf.numBits)
+	for i := uint64(0); i < uint64(bf.numHash); i++ {
+		loc := (h1 + i*h2) % numBits
+		if bf.bits[loc/64]&(1<<(loc%64)) == 0 {
 			return false
 		}
 	}
@@ -94,7 +120,9 @@ func (bf *BloomFilter[T]) EstimatedFalsePositiveRate() float64 {
 
 // Clear removes all items from the Bloom Filter.
 func (bf *BloomFilter[T]) Clear() {
-	bf.bits = make([]bool, bf.numBits)
+	for i := range bf.bits {
+		bf.bits[i] = 0
+	}
 	bf.count = 0
 }
 
@@ -117,3 +145,38 @@ func (bf *BloomFilter[T]) BitSize() uint {
 func (bf *BloomFilter[T]) NumberOfHashes() uint {
 	return bf.numHash
 }
+
+// Union adds every item of other to the filter, as if all items added to other had been added
+// to it. Both filters must have the same bit size and number of hashes, and should use the same
+// hasher; otherwise ErrIncompatible is returned. Len becomes the sum of both counts.
+func (bf *BloomFilter[T]) Union(other *BloomFilter[T]) error {
+	if !bf.compatible(other) {
+		return ErrIncompatible
+	}
+	for i, word := range other.bits {
+		bf.bits[i] |= word
+	}
+	bf.count += other.count
+	return nil
+}
+
+// Intersect keeps only the bits set in both filters, so that the filter reports the items that
+// might have been added to both. Both filters must have the same bit size and number of hashes;
+// otherwise ErrIncompatible is returned. Len becomes the smaller of both counts, an upper bound
+// of the number of common items.
+func (bf *BloomFilter[T]) Intersect(other *BloomFilter[T]) error {
+	if !bf.compatible(other) {
+		return ErrIncompatible
+	}
+	for i, word := range other.bits {
+		bf.bits[i] &= word
+	}
+	if other.count < bf.count {
+		bf.count = other.count
+	}
+	return nil
+}
+
+func (bf *BloomFilter[T]) compatible(other *BloomFilter[T]) bool {
+	return other != nil && bf.numBits == other.numBits && bf.numHash == other.numHash
+}
@@ -119,6 +119,64 @@ func TestBloomFilter_DifferentTypes(t *testing.T) {
 	)
 }
 
+func TestBloomFilter_Options(t *testing.T) {
+	type Point struct {
+		X, Y int32
+	}
+
+	t.Run(
+		"WithEncoder", func(t *testing.T) {
+			bf := NewBloomFilter[Point](
+				100, 0.01, WithEncoder(
+					func(dst []byte, p Point) []byte {
+						return append(dst, byte(p.X), byte(p.X>>8), byte(p.Y), byte(p.Y>>8))
+					},
+				),
+			)
+			bf.Add(Point{1, 2})
+			if !bf.Contains(Point{1, 2}) {
+				t.Error("Should contain point (1, 2)")
+			}
+			if bf.Contains(Point{2, 1}) {
+				t.Error("Should not contain point (2, 1)")
+			}
+		},
+	)
+
+	t.Run(
+		"WithHasher", func(t *testing.T) {
+			calls := 0
+			bf := NewBloomFilter[string](
+				100, 0.01, WithHasher(
+					func(s string) (uint64, uint64) {
+						calls++
+						return Sum128String(s)
+					},
+				),
+			)
+			bf.Add("a")
+			if !bf.Contains("a") || calls != 2 {
+				t.Errorf("Expected the custom hasher to be called once per operation, got %d calls", calls)
+			}
+		},
+	)
+
+	t.Run(
+		"No allocations", func(t *testing.T) {
+			bf := NewBloomFilter[string](100, 0.01)
+			allocs := testing.AllocsPerRun(
+				100, func() {
+					bf.Add("key")
+					bf.Contains("key")
+				},
+			)
+			if allocs != 0 {
+				t.Errorf("Expected no allocations, got %v", allocs)
+			}
+		},
+	)
+}
+
 func TestBloomFilter_EdgeCases(t *testing.T) {
 	t.Run(
 		"Zero expected items", func(t *testing.T) {
@@ -145,6 +203,20 @@ func TestBloomFilter_EdgeCases(t *testing.T) {
 			}
 		},
 	)
+	t.Run(
+		"Tiny false positive rate", func(t *testing.T) {
+			bf := NewBloomFilter[string](100, 1e-30)
+			if bf.numHash != maxHashFunctions {
+				t.Errorf("Expected %d hash functions, got %d", maxHashFunctions, bf.numHash)
+			}
+			bf.Add("test")
+			data, _ := bf.MarshalBinary()
+			var decoded BloomFilter[string]
+			if err := decoded.UnmarshalBinary(data); err != nil || !decoded.Contains("test") {
+				t.Errorf("Expected the filter to round trip, got %v", err)
+			}
+		},
+	)
 }
 
 func TestBloomFilter_Operations(t *testing.T) {
@@ -174,6 +246,69 @@ func TestBloomFilter_Operations(t *testing.T) {
 	)
 }
 
+func TestBloomFilter_UnionIntersect(t *testing.T) {
+	a := NewBloomFilter[string](100, 0.01)
+	b := NewBloomFilter[string](100, 0.01)
+	a.Add("apple")
+	a.Add("shared")
+	b.Add("banana")
+	b.Add("shared")
+
+	t.Run(
+		"Union", func(t *testing.T) {
+			u := NewBloomFilter[string](100, 0.01)
+			if err := u.Union(a); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if err := u.Union(b); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, item := range []string{"apple", "banana", "shared"} {
+				if !u.Contains(item) {
+					t.Errorf("Union should contain %s", item)
+				}
+			}
+			if u.Len() != 4 {
+				t.Errorf("Expected length 4, got %d", u.Len())
+			}
+		},
+	)
+
+	t.Run(
+		"Intersect", func(t *testing.T) {
+			i := NewBloomFilter[string](100, 0.01)
+			_ = i.Union(a)
+			if err := i.Intersect(b); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !i.Contains("shared") {
+				t.Error("Intersection should contain shared")
+			}
+			if i.Contains("apple") || i.Contains("banana") {
+				t.Error("Intersection should not contain items of a single filter")
+			}
+			if i.Len() != 2 {
+				t.Errorf("Expected length 2, got %d", i.Len())
+			}
+		},
+	)
+
+	t.Run(
+		"Incompatible", func(t *testing.T) {
+			other := NewBloomFilter[string](1000, 0.01)
+			if err := a.Union(other); err != ErrIncompatible {
+				t.Errorf("Expected ErrIncompatible, got %v", err)
+			}
+			if err := a.Intersect(NewBloomFilter[string](100, 0.001)); err != ErrIncompatible {
+				t.Errorf("Expected ErrIncompatible, got %v", err)
+			}
+			if err := a.Union(nil); err != ErrIncompatible {
+				t.Errorf("Expected ErrIncompatible, got %v", err)
+			}
+		},
+	)
+}
+
 func TestBloomFilter_FalsePositiveRate(t *testing.T) {
 	expectedItems := uint(1000)
 	targetFPR := 0.01
@@ -215,20 +350,42 @@ func TestBloomFilter_FalsePositiveRate(t *testing.T) {
 }
 
 func BenchmarkBloomFilter(b *testing.B) {
-	bf := NewBloomFilter[string](1000, 0.01)
+	const n = 1 << 16
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("item%d", i)
+	}
 
 	b.Run(
 		"Add", func(b *testing.B) {
+			bf := NewBloomFilter[string](n, 0.01)
+			b.ReportAllocs()
 			for i := 0; i < b.N; i++ {
-				bf.Add(fmt.Sprintf("item%d", i))
+				bf.Add(keys[i%n])
 			}
 		},
 	)
 
 	b.Run(
 		"Contains", func(b *testing.B) {
+			bf := NewBloomFilter[string](n, 0.01)
+			for _, key := range keys[:n/2] {
+				bf.Add(key)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				bf.Contains(keys[i%n])
+			}
+		},
+	)
+
+	b.Run(
+		"AddInt", func(b *testing.B) {
+			bf := NewBloomFilter[int](n, 0.01)
+			b.ReportAllocs()
 			for i := 0; i < b.N; i++ {
-				bf.Contains(fmt.Sprintf("item%d", i))
+				bf.Add(i)
 			}
 		},
 	)
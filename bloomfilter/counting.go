@@ -0,0 +1,133 @@
+package bloomfilter
+
+import "math"
+
+const (
+	counterBits = 4
+	counterMax  = 1<<counterBits - 1
+	counterMask = counterMax
+	// countersPerWord is the number of counters packed in each uint64
+	countersPerWord = 64 / counterBits
+)
+
+// CountingBloomFilter is a Bloom filter that supports removing items. Every bit of a BloomFilter
+// is replaced by a 4-bit counter, so it uses four times the memory of a BloomFilter with the
+// same parameters. A counter that reaches 15 sticks there and is never decremented, which keeps
+// false negatives impossible at the cost of some items never being fully removed.
+type CountingBloomFilter[T any] struct {
+	counters    []uint64
+	numCounters uint
+	numHash     uint
+	count       uint
+	hash        Hasher[T]
+}
+
+// NewCountingBloomFilter creates a counting Bloom filter sized like NewBloomFilter.
+func NewCountingBloomFilter[T any](expectedItems uint, falsePositiveProb float64, opts ...Option[T]) *CountingBloomFilter[T] {
+	cfg := newConfig(opts)
+	numCounters, numHash := optimalParameters(expectedItems, falsePositiveProb)
+	return &CountingBloomFilter[T]{
+		counters:    make([]uint64, (numCounters+countersPerWord-1)/countersPerWord),
+		numCounters: numCounters,
+		numHash:     numHash,
+		hash:        cfg.hash,
+	}
+}
+
+func (cf *CountingBloomFilter[T]) counter(loc uint64) uint64 {
+	return cf.counters[loc/countersPerWord] >> (loc % countersPerWord * counterBits) & counterMask
+}
+
+func (cf *CountingBloomFilter[T]) addCounter(loc uint64, delta int) {
+	shift := loc % countersPerWord * counterBits
+	c := cf.counters[loc/countersPerWord] >> shift & counterMask
+	if c == counterMax {
+		return // Saturated
+	}
+	if c == 0 && delta < 0 {
+		// A false positive may visit the same counter more than once; borrowing would corrupt the neighbour
+		return
+	}
+	if delta > 0 {
+		cf.counters[loc/countersPerWord] += 1 << shift
+	} else {
+		cf.counters[loc/countersPerWord] -= 1 << shift
+	}
+}
+
+// Add inserts an item into the filter.
+func (cf *CountingBloomFilter[T]) Add(item T) {
+	h1, h2 := cf.hash(item)
+	numCounters := uint64(cf.numCounters)
+	for i := uint64(0); i < uint64(cf.numHash); i++ {
+		cf.addCounter((h1+i*h2)%numCounters, 1)
+	}
+	cf.count++
+}
+
+// Remove deletes one occurrence of an item from the filter and returns true,
+// or returns false if the item is definitely not in the filter.
+// Removing an item that was never added may remove other items.
+func (cf *CountingBloomFilter[T]) Remove(item T) bool {
+	if !cf.Contains(item) {
+		return false
+	}
+	h1, h2 := cf.hash(item)
+	numCounters := uint64(cf.numCounters)
+	for i := uint64(0); i < uint64(cf.numHash); i++ {
+		cf.addCounter((h1+i*h2)%numCounters, -1)
+	}
+	if cf.count > 0 {
+		cf.count--
+	}
+	return true
+}
+
+// Contains tests whether an item might be in the filter.
+func (cf *CountingBloomFilter[T]) Contains(item T) bool {
+	h1, h2 := cf.hash(item)
+	numCounters := uint64(cf.numCounters)
+	for i := uint64(0); i < uint64(cf.numHash); i++ {
+		if cf.counter((h1+i*h2)%numCounters) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// EstimatedFalsePositiveRate returns the estimated false positive rate.
+func (cf *CountingBloomFilter[T]) EstimatedFalsePositiveRate() float64 {
+	if cf.count == 0 {
+		return 0.0
+	}
+	exponent := -float64(cf.numHash) * float64(cf.count) / float64(cf.numCounters)
+	return math.Pow(1-math.Exp(exponent), float64(cf.numHash))
+}
+
+// Clear removes all items from the filter.
+func (cf *CountingBloomFilter[T]) Clear() {
+	for i := range cf.counters {
+		cf.counters[i] = 0
+	}
+	cf.count = 0
+}
+
+// Len returns the number of items added minus the number of items removed.
+func (cf *CountingBloomFilter[T]) Len() int {
+	return int(cf.count)
+}
+
+// IsEmpty returns true if the filter holds no items.
+func (cf *CountingBloomFilter[T]) IsEmpty() bool {
+	return cf.count == 0
+}
+
+// Size returns the number of counters.
+func (cf *CountingBloomFilter[T]) Size() uint {
+	return cf.numCounters
+}
+
+// NumberOfHashes returns the number of hash functions.
+func (cf *CountingBloomFilter[T]) NumberOfHashes() uint {
+	return cf.numHash
+}
@@ -0,0 +1,166 @@
+package bloomfilter
+
+import "testing"
+
+func TestCountingBloomFilter_Basic(t *testing.T) {
+	tests := []struct {
+		name          string
+		itemsToAdd    []string
+		itemsToRemove []string
+		itemsToCheck  []string
+		shouldContain []bool
+		expectedLen   int
+	}{
+		{
+			name:          "Add only",
+			itemsToAdd:    []string{"apple", "banana"},
+			itemsToCheck:  []string{"apple", "banana", "cherry"},
+			shouldContain: []bool{true, true, false},
+			expectedLen:   2,
+		},
+		{
+			name:          "Add and remove",
+			itemsToAdd:    []string{"apple", "banana", "cherry"},
+			itemsToRemove: []string{"banana"},
+			itemsToCheck:  []string{"apple", "banana", "cherry"},
+			shouldContain: []bool{true, false, true},
+			expectedLen:   2,
+		},
+		{
+			name:          "Duplicate removed once",
+			itemsToAdd:    []string{"apple", "apple"},
+			itemsToRemove: []string{"apple"},
+			itemsToCheck:  []string{"apple"},
+			shouldContain: []bool{true},
+			expectedLen:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				cf := NewCountingBloomFilter[string](100, 0.01)
+				for _, item := range tt.itemsToAdd {
+					cf.Add(item)
+				}
+				for _, item := range tt.itemsToRemove {
+					if !cf.Remove(item) {
+						t.Errorf("Remove(%s) should succeed", item)
+					}
+				}
+
+				if cf.Len() != tt.expectedLen {
+					t.Errorf("Expected length %d, got %d", tt.expectedLen, cf.Len())
+				}
+				for i, item := range tt.itemsToCheck {
+					if cf.Contains(item) != tt.shouldContain[i] {
+						t.Errorf("Contains(%s) = %v, want %v", item, cf.Contains(item), tt.shouldContain[i])
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestCountingBloomFilter_RemoveMissing(t *testing.T) {
+	cf := NewCountingBloomFilter[int](100, 0.01)
+	cf.Add(1)
+	if cf.Remove(2) {
+		t.Error("Remove of a missing item should return false")
+	}
+	if cf.Len() != 1 || !cf.Contains(1) {
+		t.Error("Failed removal should not change the filter")
+	}
+}
+
+func TestCountingBloomFilter_RemoveAll(t *testing.T) {
+	cf := NewCountingBloomFilter[int](1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		cf.Add(i)
+	}
+	for i := 0; i < 1000; i++ {
+		if !cf.Remove(i) {
+			t.Fatalf("Remove(%d) should succeed", i)
+		}
+	}
+	if !cf.IsEmpty() {
+		t.Errorf("Expected empty filter, got length %d", cf.Len())
+	}
+	for i, word := range cf.counters {
+		if word != 0 {
+			t.Fatalf("Expected all counters to be zero, word %d is %#x", i, word)
+		}
+	}
+}
+
+func TestCountingBloomFilter_Saturation(t *testing.T) {
+	cf := NewCountingBloomFilter[string](10, 0.01)
+	for i := 0; i < counterMax+5; i++ {
+		cf.Add("hot")
+	}
+	for i := 0; i < counterMax+5; i++ {
+		cf.Remove("hot")
+	}
+	if !cf.Contains("hot") {
+		t.Error("Saturated counters should never be decremented")
+	}
+
+	// Neighbouring counters must not be affected by overflow
+	cf = NewCountingBloomFilter[string](10, 0.01)
+	for i := 0; i < 100; i++ {
+		cf.Add("hot")
+	}
+	if cf.Contains("cold") {
+		t.Error("Overflowing counters should not leak into neighbours")
+	}
+}
+
+func TestCountingBloomFilter_RepeatedPositions(t *testing.T) {
+	// "repeated" hashes to the same counter every time, which "single" sets to 1
+	positions := map[string][2]uint64{"single": {5, 1}, "repeated": {5, 0}}
+	cf := NewCountingBloomFilter[string](
+		10, 0.01, WithHasher(
+			func(s string) (uint64, uint64) {
+				return positions[s][0], positions[s][1]
+			},
+		),
+	)
+	cf.Add("single")
+	if !cf.Remove("repeated") {
+		t.Fatal("Expected a false positive for the repeated item")
+	}
+	if cf.counter(5) != 0 {
+		t.Errorf("Expected counter 5 to drop to zero, got %d", cf.counter(5))
+	}
+	if cf.counter(6) != 1 {
+		t.Errorf("Expected the neighbouring counter to stay at 1, got %d", cf.counter(6))
+	}
+}
+
+func TestCountingBloomFilter_FalsePositiveRate(t *testing.T) {
+	cf := NewCountingBloomFilter[int](1000, 0.01)
+	for i := 0; i < 2000; i++ {
+		cf.Add(i)
+	}
+	for i := 1000; i < 2000; i++ {
+		cf.Remove(i)
+	}
+
+	falsePositives := 0
+	for i := 2000; i < 12000; i++ {
+		if cf.Contains(i) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / 10000; rate > 0.02 {
+		t.Errorf("False positive rate too high after removals: %f", rate)
+	}
+	if cf.EstimatedFalsePositiveRate() > 0.02 {
+		t.Errorf("Estimated rate too high: %f", cf.EstimatedFalsePositiveRate())
+	}
+
+	cf.Clear()
+	if !cf.IsEmpty() || cf.Contains(1) {
+		t.Error("Should be empty after clear")
+	}
+}
@@ -0,0 +1,111 @@
+package bloomfilter
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/idsulik/go-collections/v3/internal/binenc"
+)
+
+// The binary encoding is a 28-byte header followed by the bitset as little-endian 64-bit words:
+//
+//	magic "BF" | version (1 byte) | reserved (1 byte) | numBits | numHash | count
+//
+// where numBits, numHash and count are little-endian uint64.
+const (
+	encodingVersion = 1
+	headerSize      = 28
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The hasher is not encoded: the decoding side must use the same one.
+func (bf *BloomFilter[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, headerSize+8*len(bf.bits))
+	bf.putHeader(data)
+	for i, word := range bf.bits {
+		binary.LittleEndian.PutUint64(data[headerSize+8*i:], word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It replaces the contents of the filter and keeps its hasher, using DefaultHasher if none is set.
+func (bf *BloomFilter[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize {
+		return fmt.Errorf("%w: data too short", ErrInvalidEncoding)
+	}
+	numBits, numHash, count, err := parseHeader(data)
+	if err != nil {
+		return err
+	}
+	if uint64(len(data)-headerSize) != 8*wordCount(numBits) {
+		return fmt.Errorf("%w: expected %d bits, got %d bytes", ErrInvalidEncoding, numBits, len(data)-headerSize)
+	}
+	words := make([]uint64, wordCount(numBits))
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[headerSize+8*i:])
+	}
+	return bf.load(numBits, numHash, count, words)
+}
+
+// WriteTo implements io.WriterTo, writing the binary encoding of the filter to w.
+func (bf *BloomFilter[T]) WriteTo(w io.Writer) (int64, error) {
+	return binenc.WriteTo(w, bf)
+}
+
+// ReadFrom implements io.ReaderFrom, reading a filter written by WriteTo from r.
+// It reads exactly the bytes of one encoded filter.
+func (bf *BloomFilter[T]) ReadFrom(r io.Reader) (int64, error) {
+	return binenc.ReadFrom(
+		r, bf, headerSize, func(data []byte) (int64, error) {
+			numBits, _, _, err := parseHeader(data)
+			return headerSize + int64(8*wordCount(numBits)), err
+		},
+	)
+}
+
+func (bf *BloomFilter[T]) putHeader(data []byte) {
+	data[0], data[1], data[2], data[3] = 'B', 'F', encodingVersion, 0
+	binary.LittleEndian.PutUint64(data[4:], uint64(bf.numBits))
+	binary.LittleEndian.PutUint64(data[12:], uint64(bf.numHash))
+	binary.LittleEndian.PutUint64(data[20:], uint64(bf.count))
+}
+
+func parseHeader(data []byte) (numBits, numHash, count uint64, err error) {
+	if data[0] != 'B' || data[1] != 'F' {
+		return 0, 0, 0, fmt.Errorf("%w: bad magic", ErrInvalidEncoding)
+	}
+	if data[2] != encodingVersion {
+		return 0, 0, 0, fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, data[2])
+	}
+	numBits = binary.LittleEndian.Uint64(data[4:])
+	numHash = binary.LittleEndian.Uint64(data[12:])
+	count = binary.LittleEndian.Uint64(data[20:])
+	if numBits == 0 || uint64(uint(numBits)) != numBits || numBits > numBits+63 {
+		return 0, 0, 0, fmt.Errorf("%w: invalid parameters", ErrInvalidEncoding)
+	}
+	if numHash == 0 || numHash > maxHashFunctions {
+		return 0, 0, 0, fmt.Errorf("%w: invalid number of hash functions %d", ErrInvalidEncoding, numHash)
+	}
+	return numBits, numHash, count, nil
+}
+
+// load replaces the state of the filter with decoded values.
+func (bf *BloomFilter[T]) load(numBits, numHash, count uint64, words []uint64) error {
+	if rem := numBits % 64; rem != 0 && words[len(words)-1]>>rem != 0 {
+		return fmt.Errorf("%w: bits set beyond the bitset size", ErrInvalidEncoding)
+	}
+	bf.bits = words
+	bf.numBits = uint(numBits)
+	bf.numHash = uint(numHash)
+	bf.count = uint(count)
+	if bf.hash == nil {
+		bf.hash = DefaultHasher[T]()
+	}
+	return nil
+}
+
+func wordCount(numBits uint64) uint64 {
+	return (numBits + 63) / 64
+}
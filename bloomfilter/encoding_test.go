@@ -0,0 +1,117 @@
+package bloomfilter
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestBloomFilter_MarshalBinary(t *testing.T) {
+	bf := NewBloomFilter[string](1000, 0.01)
+	for _, item := range []string{"apple", "banana", "cherry"} {
+		bf.Add(item)
+	}
+
+	data, err := bf.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var decoded BloomFilter[string]
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertSameFilter(t, bf, &decoded)
+}
+
+func TestBloomFilter_UnmarshalBinaryErrors(t *testing.T) {
+	bf := NewBloomFilter[int](100, 0.01)
+	bf.Add(1)
+	valid, _ := bf.MarshalBinary()
+
+	corrupt := func(f func(data []byte) []byte) []byte {
+		return f(append([]byte(nil), valid...))
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"Empty", nil},
+		{"Short header", valid[:10]},
+		{"Bad magic", corrupt(func(d []byte) []byte { d[0] = 'X'; return d })},
+		{"Unknown version", corrupt(func(d []byte) []byte { d[2] = 99; return d })},
+		{"Zero bits", corrupt(func(d []byte) []byte { d[4], d[5] = 0, 0; return d })},
+		{"Zero hashes", corrupt(func(d []byte) []byte { d[12] = 0; return d })},
+		{"Too many hashes", corrupt(func(d []byte) []byte { d[12] = maxHashFunctions + 1; return d })},
+		{"Huge hash count", corrupt(func(d []byte) []byte { d[19] = 0xFF; return d })},
+		{"Truncated bitset", valid[:len(valid)-1]},
+		{"Trailing bytes", append(append([]byte(nil), valid...), 0)},
+		{"Bits beyond size", corrupt(func(d []byte) []byte { d[len(d)-1] = 0x80; return d })},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				var decoded BloomFilter[int]
+				if err := decoded.UnmarshalBinary(tt.data); !errors.Is(err, ErrInvalidEncoding) {
+					t.Errorf("Expected ErrInvalidEncoding, got %v", err)
+				}
+			},
+		)
+	}
+}
+
+func TestBloomFilter_WriteToReadFrom(t *testing.T) {
+	bf := NewBloomFilter[int](5000, 0.001)
+	for i := 0; i < 5000; i += 3 {
+		bf.Add(i)
+	}
+
+	var buf bytes.Buffer
+	written, err := bf.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if written != int64(buf.Len()) {
+		t.Errorf("WriteTo reported %d bytes, wrote %d", written, buf.Len())
+	}
+	buf.WriteString("trailer")
+
+	decoded := NewBloomFilter[int](1, 0.5)
+	read, err := decoded.ReadFrom(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if read != written {
+		t.Errorf("ReadFrom read %d bytes, expected %d", read, written)
+	}
+	if buf.String() != "trailer" {
+		t.Errorf("ReadFrom should not consume bytes past the filter, left %q", buf.String())
+	}
+	assertSameFilter(t, bf, decoded)
+
+	data, _ := bf.MarshalBinary()
+	if _, err := decoded.ReadFrom(bytes.NewReader(data[:len(data)-8])); err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
+
+func assertSameFilter[T any](t *testing.T, expected, actual *BloomFilter[T]) {
+	t.Helper()
+	if actual.BitSize() != expected.BitSize() || actual.NumberOfHashes() != expected.NumberOfHashes() {
+		t.Fatalf(
+			"Expected %d bits and %d hashes, got %d and %d",
+			expected.BitSize(), expected.NumberOfHashes(), actual.BitSize(), actual.NumberOfHashes(),
+		)
+	}
+	if actual.Len() != expected.Len() {
+		t.Errorf("Expected length %d, got %d", expected.Len(), actual.Len())
+	}
+	for i := range expected.bits {
+		if actual.bits[i] != expected.bits[i] {
+			t.Fatalf("Bitsets differ at word %d", i)
+		}
+	}
+}
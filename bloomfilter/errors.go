@@ -0,0 +1,11 @@
+package bloomfilter
+
+import "errors"
+
+var (
+	// ErrIncompatible is returned when combining filters with a different number of bits or hash functions.
+	ErrIncompatible = errors.New("bloomfilter: filters have different parameters")
+
+	// ErrInvalidEncoding is returned when decoding data that is not a valid encoded filter.
+	ErrInvalidEncoding = errors.New("bloomfilter: invalid encoding")
+)
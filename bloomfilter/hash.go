@@ -0,0 +1,179 @@
+package bloomfilter
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// Hasher computes a 128-bit hash of an item, returned as two 64-bit halves.
+// Both halves should be well distributed, as they are combined by double hashing.
+type Hasher[T any] func(item T) (uint64, uint64)
+
+// Encoder appends a byte representation of an item to dst and returns the extended slice.
+// Equal items must produce equal bytes.
+type Encoder[T any] func(dst []byte, item T) []byte
+
+// DefaultHasher returns the Hasher used when none is configured. Strings, byte slices, booleans
+// and numeric types are hashed from their bytes without allocating; other types are hashed
+// from their fmt "%v" representation.
+func DefaultHasher[T any]() Hasher[T] {
+	return func(item T) (uint64, uint64) {
+		switch v := any(item).(type) {
+		case string:
+			return Sum128String(v)
+		case []byte:
+			return Sum128(v)
+		}
+
+		var buf [8]byte
+		if b, ok := putScalar(&buf, item); ok {
+			return Sum128(b)
+		}
+		return Sum128String(fmt.Sprintf("%v", item))
+	}
+}
+
+// EncoderHasher returns a Hasher that hashes the bytes produced by enc.
+func EncoderHasher[T any](enc Encoder[T]) Hasher[T] {
+	return func(item T) (uint64, uint64) {
+		var buf [64]byte
+		return Sum128(enc(buf[:0], item))
+	}
+}
+
+// putScalar stores the little-endian bytes of booleans and numeric values in buf.
+func putScalar(buf *[8]byte, v any) ([]byte, bool) {
+	le := binary.LittleEndian
+	switch v := v.(type) {
+	case bool:
+		if v {
+			buf[0] = 1
+		}
+		return buf[:1], true
+	case int:
+		le.PutUint64(buf[:], uint64(v))
+	case int8:
+		buf[0] = byte(v)
+		return buf[:1], true
+	case int16:
+		le.PutUint16(buf[:], uint16(v))
+		return buf[:2], true
+	case int32:
+		le.PutUint32(buf[:], uint32(v))
+		return buf[:4], true
+	case int64:
+		le.PutUint64(buf[:], uint64(v))
+	case uint:
+		le.PutUint64(buf[:], uint64(v))
+	case uint8:
+		buf[0] = v
+		return buf[:1], true
+	case uint16:
+		le.PutUint16(buf[:], v)
+		return buf[:2], true
+	case uint32:
+		le.PutUint32(buf[:], v)
+		return buf[:4], true
+	case uint64:
+		le.PutUint64(buf[:], v)
+	case uintptr:
+		le.PutUint64(buf[:], uint64(v))
+	case float32:
+		le.PutUint32(buf[:], math.Float32bits(v))
+		return buf[:4], true
+	case float64:
+		le.PutUint64(buf[:], math.Float64bits(v))
+	default:
+		return nil, false
+	}
+	return buf[:], true
+}
+
+const (
+	murmurC1 = 0x87c37b91114253d5
+	murmurC2 = 0x4cf5ad432745937f
+)
+
+// Sum128 returns the 128-bit MurmurHash3 (x64 variant, seed 0) of data.
+func Sum128(data []byte) (uint64, uint64) {
+	return murmur3(data)
+}
+
+// Sum128String is like Sum128 but hashes the bytes of a string without copying them.
+func Sum128String(s string) (uint64, uint64) {
+	return murmur3(s)
+}
+
+func murmur3[B string | []byte](data B) (uint64, uint64) {
+	var h1, h2 uint64
+	n := len(data)
+
+	for len(data) >= 16 {
+		k1 := load64(data[:8])
+		k2 := load64(data[8:16])
+		data = data[16:]
+
+		h1 ^= mixK1(k1)
+		h1 = bits.RotateLeft64(h1, 27) + h2
+		h1 = h1*5 + 0x52dce729
+
+		h2 ^= mixK2(k2)
+		h2 = bits.RotateLeft64(h2, 31) + h1
+		h2 = h2*5 + 0x38495ab5
+	}
+
+	// Up to 15 remaining bytes
+	if len(data) > 8 {
+		h2 ^= mixK2(load64(data[8:]))
+		data = data[:8]
+	}
+	if len(data) > 0 {
+		h1 ^= mixK1(load64(data))
+	}
+
+	h1 ^= uint64(n)
+	h2 ^= uint64(n)
+	h1 += h2
+	h2 += h1
+	h1 = fmix64(h1)
+	h2 = fmix64(h2)
+	h1 += h2
+	h2 += h1
+	return h1, h2
+}
+
+// load64 reads up to 8 bytes as a little-endian integer.
+func load64[B string | []byte](b B) uint64 {
+	if len(b) >= 8 {
+		return uint64(b[0]) | uint64(b[1])<<8 | uint64(b[2])<<16 | uint64(b[3])<<24 |
+			uint64(b[4])<<32 | uint64(b[5])<<40 | uint64(b[6])<<48 | uint64(b[7])<<56
+	}
+	var k uint64
+	for i := len(b) - 1; i >= 0; i-- {
+		k = k<<8 | uint64(b[i])
+	}
+	return k
+}
+
+func mixK1(k uint64) uint64 {
+	k *= murmurC1
+	k = bits.RotateLeft64(k, 31)
+	return k * murmurC2
+}
+
+func mixK2(k uint64) uint64 {
+	k *= murmurC2
+	k = bits.RotateLeft64(k, 33)
+	return k * murmurC1
+}
+
+func fmix64(k uint64) uint64 {
+	k ^= k >> 33
+	k *= 0xff51afd7ed558ccd
+	k ^= k >> 33
+	k *= 0xc4ceb9fe1a85ec53
+	k ^= k >> 33
+	return k
+}
@@ -0,0 +1,71 @@
+package bloomfilter
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestSum128(t *testing.T) {
+	tests := []struct {
+		input  string
+		h1, h2 uint64
+	}{
+		{"", 0, 0},
+		{"hello", 0xcbd8a7b341bd9b02, 0x5b1e906a48ae1d19},
+		{"hello, world", 0x342fac623a5ebc8e, 0x4cdcbc079642414d},
+		{"The quick brown fox jumps over the lazy dog", 0xe34bbc7bbc071b6c, 0x7a433ca9c49a9347},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.input, func(t *testing.T) {
+				if h1, h2 := Sum128([]byte(tt.input)); h1 != tt.h1 || h2 != tt.h2 {
+					t.Errorf("Sum128(%q) = %#x, %#x, want %#x, %#x", tt.input, h1, h2, tt.h1, tt.h2)
+				}
+				if h1, h2 := Sum128String(tt.input); h1 != tt.h1 || h2 != tt.h2 {
+					t.Errorf("Sum128String(%q) = %#x, %#x, want %#x, %#x", tt.input, h1, h2, tt.h1, tt.h2)
+				}
+			},
+		)
+	}
+}
+
+func TestDefaultHasher(t *testing.T) {
+	t.Run(
+		"Scalars hash their little-endian bytes", func(t *testing.T) {
+			var buf [8]byte
+			binary.LittleEndian.PutUint64(buf[:], 42)
+			h1, h2 := DefaultHasher[int]()(42)
+			w1, w2 := Sum128(buf[:])
+			if h1 != w1 || h2 != w2 {
+				t.Errorf("Expected int to be hashed from its bytes")
+			}
+		},
+	)
+
+	t.Run(
+		"Strings and byte slices agree", func(t *testing.T) {
+			s1, s2 := DefaultHasher[string]()("abc")
+			b1, b2 := DefaultHasher[[]byte]()([]byte("abc"))
+			if s1 != b1 || s2 != b2 {
+				t.Errorf("Expected string and []byte to hash equally")
+			}
+		},
+	)
+
+	t.Run(
+		"No allocations", func(t *testing.T) {
+			hs := DefaultHasher[string]()
+			hi := DefaultHasher[int64]()
+			allocs := testing.AllocsPerRun(
+				100, func() {
+					hs("some key")
+					hi(12345)
+				},
+			)
+			if allocs != 0 {
+				t.Errorf("Expected no allocations, got %v", allocs)
+			}
+		},
+	)
+}
@@ -0,0 +1,172 @@
+package bloomfilter
+
+// ScalableBloomFilter is a Bloom filter that grows with the number of items while keeping the false
+// positive rate bounded. When its active filter reaches capacity, it adds a new filter with a larger
+// capacity and a tighter error rate, so the compound error rate converges to at most the target.
+// It follows "Scalable Bloom Filters" (Almeida et al., 2007).
+type ScalableBloomFilter[T any] struct {
+	filters           []*BloomFilter[T]
+	capacities        []uint
+	probs             []float64
+	initialCapacity   uint
+	falsePositiveProb float64
+	cfg               scalableConfig[T]
+}
+
+// ScalableOption configures a ScalableBloomFilter. Every Option, such as WithHasher, is also a ScalableOption,
+// while the options that only make sense for a ScalableBloomFilter cannot be passed to the other filters.
+type ScalableOption[T any] interface {
+	applyScalable(cfg *scalableConfig[T])
+}
+
+type scalableConfig[T any] struct {
+	config[T]
+	growthFactor    uint
+	tighteningRatio float64
+}
+
+func (opt Option[T]) applyScalable(cfg *scalableConfig[T]) {
+	opt(&cfg.config)
+}
+
+// scalableOption is a ScalableOption that is not an Option.
+type scalableOption[T any] func(cfg *scalableConfig[T])
+
+func (opt scalableOption[T]) applyScalable(cfg *scalableConfig[T]) {
+	opt(cfg)
+}
+
+func newScalableConfig[T any](opts []ScalableOption[T]) scalableConfig[T] {
+	cfg := scalableConfig[T]{
+		config:          newConfig[T](nil),
+		growthFactor:    2,
+		tighteningRatio: 0.85,
+	}
+	for _, opt := range opts {
+		opt.applyScalable(&cfg)
+	}
+	return cfg
+}
+
+// WithGrowthFactor sets how many times the capacity of each new filter of a ScalableBloomFilter
+// exceeds the previous one. The default is 2. Values below 1 are ignored.
+func WithGrowthFactor[T any](factor uint) ScalableOption[T] {
+	return scalableOption[T](
+		func(cfg *scalableConfig[T]) {
+			if factor >= 1 {
+				cfg.growthFactor = factor
+			}
+		},
+	)
+}
+
+// WithTighteningRatio sets the ratio, between 0 and 1, by which the error rate of each new filter
+// of a ScalableBloomFilter is multiplied. The default is 0.85. Smaller ratios use more memory per
+// filter but keep the compound error rate further below the target. Values outside (0, 1) are ignored.
+func WithTighteningRatio[T any](ratio float64) ScalableOption[T] {
+	return scalableOption[T](
+		func(cfg *scalableConfig[T]) {
+			if ratio > 0 && ratio < 1 {
+				cfg.tighteningRatio = ratio
+			}
+		},
+	)
+}
+
+// NewScalableBloomFilter creates a scalable Bloom filter whose first filter holds initialCapacity
+// items and whose compound false positive rate stays below falsePositiveProb.
+func NewScalableBloomFilter[T any](initialCapacity uint, falsePositiveProb float64, opts ...ScalableOption[T]) *ScalableBloomFilter[T] {
+	if initialCapacity == 0 {
+		initialCapacity = 1
+	}
+	if falsePositiveProb <= 0 || falsePositiveProb >= 1 {
+		falsePositiveProb = 0.01
+	}
+	sf := &ScalableBloomFilter[T]{
+		initialCapacity:   initialCapacity,
+		falsePositiveProb: falsePositiveProb,
+		cfg:               newScalableConfig(opts),
+	}
+	sf.grow()
+	return sf
+}
+
+// grow appends a new filter. The error rate of filter i is P * (1-r) * r^i, whose sum over all
+// filters is at most the target rate P.
+func (sf *ScalableBloomFilter[T]) grow() {
+	capacity := sf.initialCapacity
+	prob := sf.falsePositiveProb * (1 - sf.cfg.tighteningRatio)
+	if n := len(sf.filters); n > 0 {
+		capacity = sf.capacities[n-1] * sf.cfg.growthFactor
+		prob = sf.probs[n-1] * sf.cfg.tighteningRatio
+	}
+	sf.filters = append(sf.filters, newBloomFilter(capacity, prob, sf.cfg.hash))
+	sf.capacities = append(sf.capacities, capacity)
+	sf.probs = append(sf.probs, prob)
+}
+
+// Add inserts an item into the filter, adding a new filter first if the active one is full.
+func (sf *ScalableBloomFilter[T]) Add(item T) {
+	last := len(sf.filters) - 1
+	if sf.filters[last].count >= sf.capacities[last] {
+		sf.grow()
+		last++
+	}
+	sf.filters[last].Add(item)
+}
+
+// Contains tests whether an item might be in the filter.
+func (sf *ScalableBloomFilter[T]) Contains(item T) bool {
+	h1, h2 := sf.cfg.hash(item)
+	for i := len(sf.filters) - 1; i >= 0; i-- {
+		if sf.filters[i].containsHash(h1, h2) {
+			return true
+		}
+	}
+	return false
+}
+
+// EstimatedFalsePositiveRate returns the estimated compound false positive rate of all filters.
+func (sf *ScalableBloomFilter[T]) EstimatedFalsePositiveRate() float64 {
+	negative := 1.0
+	for _, bf := range sf.filters {
+		negative *= 1 - bf.EstimatedFalsePositiveRate()
+	}
+	return 1 - negative
+}
+
+// Clear removes all items from the filter and releases all filters but the first.
+func (sf *ScalableBloomFilter[T]) Clear() {
+	sf.filters[0].Clear()
+	sf.filters = sf.filters[:1]
+	sf.capacities = sf.capacities[:1]
+	sf.probs = sf.probs[:1]
+}
+
+// Len returns the number of items added to the filter.
+func (sf *ScalableBloomFilter[T]) Len() int {
+	count := 0
+	for _, bf := range sf.filters {
+		count += bf.Len()
+	}
+	return count
+}
+
+// IsEmpty returns true if no items have been added to the filter.
+func (sf *ScalableBloomFilter[T]) IsEmpty() bool {
+	return sf.Len() == 0
+}
+
+// FilterCount returns the number of chained filters.
+func (sf *ScalableBloomFilter[T]) FilterCount() int {
+	return len(sf.filters)
+}
+
+// BitSize returns the total size of the bit arrays of all filters.
+func (sf *ScalableBloomFilter[T]) BitSize() uint {
+	var size uint
+	for _, bf := range sf.filters {
+		size += bf.BitSize()
+	}
+	return size
+}
@@ -0,0 +1,108 @@
+package bloomfilter
+
+import "testing"
+
+func TestScalableBloomFilter_Grow(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            []ScalableOption[int]
+		items           int
+		expectedFilters int
+	}{
+		{"Within capacity", nil, 100, 1},
+		{"Default growth", nil, 1000, 4},                                              // 100 + 200 + 400 + 800
+		{"Growth factor 4", []ScalableOption[int]{WithGrowthFactor[int](4)}, 1000, 3}, // 100 + 400 + 1600
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				sf := NewScalableBloomFilter[int](100, 0.01, tt.opts...)
+				for i := 0; i < tt.items; i++ {
+					sf.Add(i)
+				}
+				if sf.FilterCount() != tt.expectedFilters {
+					t.Errorf("Expected %d filters, got %d", tt.expectedFilters, sf.FilterCount())
+				}
+				if sf.Len() != tt.items {
+					t.Errorf("Expected length %d, got %d", tt.items, sf.Len())
+				}
+				for i := 0; i < tt.items; i++ {
+					if !sf.Contains(i) {
+						t.Fatalf("Should contain %d", i)
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestScalableBloomFilter_FalsePositiveRate(t *testing.T) {
+	const target = 0.01
+	sf := NewScalableBloomFilter[int](100, target)
+	plain := NewBloomFilter[int](100, target)
+	for i := 0; i < 10000; i++ {
+		sf.Add(i)
+		plain.Add(i)
+	}
+
+	falsePositives := 0
+	trials := 20000
+	for i := 10000; i < 10000+trials; i++ {
+		if sf.Contains(i) {
+			falsePositives++
+		}
+	}
+
+	actual := float64(falsePositives) / float64(trials)
+	if actual > target {
+		t.Errorf("False positive rate %f exceeds target %f", actual, target)
+	}
+	if estimated := sf.EstimatedFalsePositiveRate(); estimated > target {
+		t.Errorf("Estimated false positive rate %f exceeds target %f", estimated, target)
+	}
+	if plain.EstimatedFalsePositiveRate() < 0.5 {
+		t.Errorf("Expected an overfilled fixed filter to degrade, got %f", plain.EstimatedFalsePositiveRate())
+	}
+}
+
+func TestScalableBloomFilter_Clear(t *testing.T) {
+	sf := NewScalableBloomFilter[string](2, 0.01, WithTighteningRatio[string](0.5))
+	for _, item := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		sf.Add(item)
+	}
+	if sf.FilterCount() != 3 {
+		t.Errorf("Expected 3 filters, got %d", sf.FilterCount())
+	}
+
+	sf.Clear()
+	if !sf.IsEmpty() || sf.FilterCount() != 1 || sf.Contains("a") {
+		t.Error("Should be a single empty filter after clear")
+	}
+	if sf.EstimatedFalsePositiveRate() != 0 {
+		t.Errorf("Expected zero false positive rate, got %f", sf.EstimatedFalsePositiveRate())
+	}
+}
+
+func TestScalableBloomFilter_Options(t *testing.T) {
+	calls := 0
+	sf := NewScalableBloomFilter[string](
+		2, 0.01,
+		WithGrowthFactor[string](3),
+		WithHasher(
+			func(s string) (uint64, uint64) {
+				calls++
+				return Sum128String(s)
+			},
+		),
+	)
+	for _, item := range []string{"a", "b", "c"} {
+		sf.Add(item)
+	}
+	if sf.FilterCount() != 2 || sf.capacities[1] != 6 {
+		t.Errorf("Expected a second filter of capacity 6, got %d filters", sf.FilterCount())
+	}
+	if calls == 0 || !sf.Contains("a") {
+		t.Error("Expected the custom hasher to be used")
+	}
+}
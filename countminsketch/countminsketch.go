@@ -0,0 +1,168 @@
+package countminsketch
+
+import (
+	"math"
+
+	"github.com/idsulik/go-collections/v3/bloomfilter"
+)
+
+// CountMinSketch estimates the frequency of items in bounded memory. It holds depth rows of width
+// counters; an item increments one counter per row and its count is estimated by the smallest of
+// them. Estimates never undercount, and overcount by at most epsilon*TotalCount() with probability
+// 1-delta, where epsilon = e/width and delta = e^-depth.
+type CountMinSketch[T any] struct {
+	counters     []uint64 // depth rows of width counters
+	width        uint
+	depth        uint
+	total        uint64
+	conservative bool
+	hash         bloomfilter.Hasher[T]
+}
+
+// Option is a function that configures a CountMinSketch.
+type Option[T any] func(*CountMinSketch[T])
+
+// WithConservativeUpdate makes Add only raise the counters that are below the new estimate of the item,
+// which greatly reduces overcounting. Sketches with conservative update can still be merged, but
+// counts can no longer be subtracted.
+func WithConservativeUpdate[T any]() Option[T] {
+	return func(s *CountMinSketch[T]) {
+		s.conservative = true
+	}
+}
+
+// WithHasher sets the function used to hash items.
+func WithHasher[T any](hasher bloomfilter.Hasher[T]) Option[T] {
+	return func(s *CountMinSketch[T]) {
+		s.hash = hasher
+	}
+}
+
+// WithEncoder hashes items from the bytes produced by enc.
+func WithEncoder[T any](enc bloomfilter.Encoder[T]) Option[T] {
+	return func(s *CountMinSketch[T]) {
+		s.hash = bloomfilter.EncoderHasher(enc)
+	}
+}
+
+// New creates a sketch with depth rows of width counters. Zero dimensions are raised to 1.
+func New[T any](width, depth uint, opts ...Option[T]) *CountMinSketch[T] {
+	if width == 0 {
+		width = 1
+	}
+	if depth == 0 {
+		depth = 1
+	}
+	s := &CountMinSketch[T]{
+		counters: make([]uint64, width*depth),
+		width:    width,
+		depth:    depth,
+		hash:     bloomfilter.DefaultHasher[T](),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NewWithEstimates creates a sketch whose estimates exceed the true counts by at most
+// epsilon*TotalCount() with probability 1-delta. Invalid values default to 0.001 and 0.01.
+func NewWithEstimates[T any](epsilon, delta float64, opts ...Option[T]) *CountMinSketch[T] {
+	if epsilon <= 0 || epsilon >= 1 {
+		epsilon = 0.001
+	}
+	if delta <= 0 || delta >= 1 {
+		delta = 0.01
+	}
+	width := uint(math.Ceil(math.E / epsilon))
+	depth := uint(math.Ceil(math.Log(1 / delta)))
+	return New[T](width, depth, opts...)
+}
+
+// Add increases the count of an item and returns its new estimated count.
+func (s *CountMinSketch[T]) Add(item T, count uint64) uint64 {
+	h1, h2 := s.hash(item)
+	s.total += count
+
+	if s.conservative {
+		estimate := s.estimate(h1, h2) + count
+		for row := uint64(0); row < uint64(s.depth); row++ {
+			if i := s.index(row, h1, h2); s.counters[i] < estimate {
+				s.counters[i] = estimate
+			}
+		}
+		return estimate
+	}
+
+	estimate := uint64(math.MaxUint64)
+	for row := uint64(0); row < uint64(s.depth); row++ {
+		i := s.index(row, h1, h2)
+		s.counters[i] += count
+		if s.counters[i] < estimate {
+			estimate = s.counters[i]
+		}
+	}
+	return estimate
+}
+
+// Count returns the estimated count of an item. It is never less than the true count.
+func (s *CountMinSketch[T]) Count(item T) uint64 {
+	h1, h2 := s.hash(item)
+	return s.estimate(h1, h2)
+}
+
+// index returns the position of the counter of a hash in a row, derived by double hashing.
+func (s *CountMinSketch[T]) index(row, h1, h2 uint64) uint64 {
+	return row*uint64(s.width) + (h1+row*h2)%uint64(s.width)
+}
+
+func (s *CountMinSketch[T]) estimate(h1, h2 uint64) uint64 {
+	estimate := uint64(math.MaxUint64)
+	for row := uint64(0); row < uint64(s.depth); row++ {
+		if c := s.counters[s.index(row, h1, h2)]; c < estimate {
+			estimate = c
+		}
+	}
+	return estimate
+}
+
+// Merge adds all counts of other to the sketch. Both sketches must have the same width and depth,
+// and should use the same hasher; otherwise ErrIncompatible is returned.
+func (s *CountMinSketch[T]) Merge(other *CountMinSketch[T]) error {
+	if other == nil || s.width != other.width || s.depth != other.depth {
+		return ErrIncompatible
+	}
+	for i, c := range other.counters {
+		s.counters[i] += c
+	}
+	s.total += other.total
+	return nil
+}
+
+// TotalCount returns the sum of all counts added to the sketch.
+func (s *CountMinSketch[T]) TotalCount() uint64 {
+	return s.total
+}
+
+// Clear resets all counts to zero.
+func (s *CountMinSketch[T]) Clear() {
+	for i := range s.counters {
+		s.counters[i] = 0
+	}
+	s.total = 0
+}
+
+// IsEmpty returns true if nothing has been added to the sketch.
+func (s *CountMinSketch[T]) IsEmpty() bool {
+	return s.total == 0
+}
+
+// Width returns the number of counters per row.
+func (s *CountMinSketch[T]) Width() uint {
+	return s.width
+}
+
+// Depth returns the number of rows.
+func (s *CountMinSketch[T]) Depth() uint {
+	return s.depth
+}
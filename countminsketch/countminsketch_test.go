@@ -0,0 +1,146 @@
+package countminsketch
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestNewWithEstimates(t *testing.T) {
+	tests := []struct {
+		epsilon, delta float64
+		width, depth   uint
+	}{
+		{0.01, 0.01, 272, 5},
+		{0.001, 0.001, 2719, 7},
+		{0, 2, 2719, 5}, // Defaults
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			fmt.Sprintf("epsilon=%v delta=%v", tt.epsilon, tt.delta), func(t *testing.T) {
+				s := NewWithEstimates[string](tt.epsilon, tt.delta)
+				if s.Width() != tt.width || s.Depth() != tt.depth {
+					t.Errorf("Expected %dx%d, got %dx%d", tt.depth, tt.width, s.Depth(), s.Width())
+				}
+			},
+		)
+	}
+}
+
+func TestCountMinSketch_Count(t *testing.T) {
+	for _, conservative := range []bool{false, true} {
+		t.Run(
+			fmt.Sprintf("conservative=%v", conservative), func(t *testing.T) {
+				var opts []Option[int]
+				if conservative {
+					opts = append(opts, WithConservativeUpdate[int]())
+				}
+				s := NewWithEstimates[int](0.001, 0.01, opts...)
+
+				// Zipf-like frequencies: item i occurs 10000/(i+1) times
+				truth := make(map[int]uint64)
+				for i := 0; i < 2000; i++ {
+					truth[i] = uint64(10000 / (i + 1))
+					s.Add(i, truth[i])
+				}
+
+				bound := uint64(math.Ceil(0.001 * float64(s.TotalCount())))
+				for item, count := range truth {
+					estimate := s.Count(item)
+					if estimate < count {
+						t.Fatalf("Count(%d) = %d underestimates %d", item, estimate, count)
+					}
+					if estimate-count > bound {
+						t.Errorf("Count(%d) = %d exceeds %d by more than %d", item, estimate, count, bound)
+					}
+				}
+				if s.Count(-1) > bound {
+					t.Errorf("Count of a missing item %d exceeds %d", s.Count(-1), bound)
+				}
+			},
+		)
+	}
+}
+
+func TestCountMinSketch_ConservativeUpdate(t *testing.T) {
+	plain := New[int](64, 4)
+	conservative := New[int](64, 4, WithConservativeUpdate[int]())
+	for i := 0; i < 1000; i++ {
+		plain.Add(i, 1)
+		conservative.Add(i, 1)
+	}
+
+	var plainError, conservativeError uint64
+	for i := 0; i < 1000; i++ {
+		plainError += plain.Count(i) - 1
+		conservativeError += conservative.Count(i) - 1
+	}
+	if conservativeError >= plainError {
+		t.Errorf("Expected conservative update to overcount less: %d vs %d", conservativeError, plainError)
+	}
+}
+
+func TestCountMinSketch_Add(t *testing.T) {
+	s := New[string](100, 3)
+	if got := s.Add("a", 3); got != 3 {
+		t.Errorf("Expected estimate 3, got %d", got)
+	}
+	if got := s.Add("a", 2); got != 5 {
+		t.Errorf("Expected estimate 5, got %d", got)
+	}
+	if s.TotalCount() != 5 || s.IsEmpty() {
+		t.Errorf("Expected total 5, got %d", s.TotalCount())
+	}
+
+	s.Clear()
+	if !s.IsEmpty() || s.Count("a") != 0 {
+		t.Error("Should be empty after clear")
+	}
+}
+
+func TestCountMinSketch_Merge(t *testing.T) {
+	a := New[string](100, 4)
+	b := New[string](100, 4)
+	a.Add("x", 3)
+	a.Add("y", 1)
+	b.Add("x", 4)
+	b.Add("z", 2)
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for item, expected := range map[string]uint64{"x": 7, "y": 1, "z": 2} {
+		if got := a.Count(item); got != expected {
+			t.Errorf("Count(%s) = %d, want %d", item, got, expected)
+		}
+	}
+	if a.TotalCount() != 10 {
+		t.Errorf("Expected total 10, got %d", a.TotalCount())
+	}
+
+	if err := a.Merge(New[string](100, 5)); err != ErrIncompatible {
+		t.Errorf("Expected ErrIncompatible, got %v", err)
+	}
+	if err := a.Merge(nil); err != ErrIncompatible {
+		t.Errorf("Expected ErrIncompatible, got %v", err)
+	}
+}
+
+func BenchmarkCountMinSketch(b *testing.B) {
+	for _, conservative := range []bool{false, true} {
+		b.Run(
+			fmt.Sprintf("Add/conservative=%v", conservative), func(b *testing.B) {
+				var opts []Option[int]
+				if conservative {
+					opts = append(opts, WithConservativeUpdate[int]())
+				}
+				s := NewWithEstimates[int](0.001, 0.01, opts...)
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					s.Add(i%10000, 1)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,126 @@
+package countminsketch
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/bits"
+
+	"github.com/idsulik/go-collections/v3/bloomfilter"
+	"github.com/idsulik/go-collections/v3/internal/binenc"
+)
+
+// The binary encoding is a 24-byte header followed by the counters row by row:
+//
+//	magic "CM" | version | flags | depth | width | total
+//
+// where depth is a little-endian uint32, width and total are little-endian uint64 and the lowest
+// bit of flags marks conservative update. Counters are little-endian uint64.
+const (
+	encodingVersion  = 1
+	headerSize       = 24
+	flagConservative = 1
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The hasher is not encoded: the decoding side must use the same one.
+func (s *CountMinSketch[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, headerSize+8*len(s.counters))
+	data[0], data[1], data[2] = 'C', 'M', encodingVersion
+	if s.conservative {
+		data[3] = flagConservative
+	}
+	binary.LittleEndian.PutUint32(data[4:], uint32(s.depth))
+	binary.LittleEndian.PutUint64(data[8:], uint64(s.width))
+	binary.LittleEndian.PutUint64(data[16:], s.total)
+	for i, c := range s.counters {
+		binary.LittleEndian.PutUint64(data[headerSize+8*i:], c)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It replaces the contents of the sketch and keeps its hasher, using the default one if none is set.
+func (s *CountMinSketch[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize {
+		return fmt.Errorf("%w: data too short", ErrInvalidEncoding)
+	}
+	decoded, err := parseHeader[T](data)
+	if err != nil {
+		return err
+	}
+	if uint64(len(data)-headerSize) != 8*decoded.size() {
+		return fmt.Errorf("%w: expected %d counters, got %d bytes", ErrInvalidEncoding, decoded.size(), len(data)-headerSize)
+	}
+
+	decoded.counters = make([]uint64, decoded.size())
+	for i := range decoded.counters {
+		decoded.counters[i] = binary.LittleEndian.Uint64(data[headerSize+8*i:])
+	}
+	// Every count is added to one counter per row, so no row can sum to more than the total
+	for row := uint(0); row < decoded.depth; row++ {
+		sum, carry := uint64(0), uint64(0)
+		for _, c := range decoded.counters[row*decoded.width : (row+1)*decoded.width] {
+			if sum, carry = bits.Add64(sum, c, 0); carry != 0 {
+				break
+			}
+		}
+		if carry != 0 || sum > decoded.total {
+			return fmt.Errorf("%w: row %d exceeds the total count", ErrInvalidEncoding, row)
+		}
+	}
+
+	decoded.hash = s.hash
+	if decoded.hash == nil {
+		decoded.hash = bloomfilter.DefaultHasher[T]()
+	}
+	*s = *decoded
+	return nil
+}
+
+// WriteTo implements io.WriterTo, writing the binary encoding of the sketch to w.
+func (s *CountMinSketch[T]) WriteTo(w io.Writer) (int64, error) {
+	return binenc.WriteTo(w, s)
+}
+
+// ReadFrom implements io.ReaderFrom, reading a sketch written by WriteTo from r.
+// It reads exactly the bytes of one encoded sketch.
+func (s *CountMinSketch[T]) ReadFrom(r io.Reader) (int64, error) {
+	return binenc.ReadFrom(
+		r, s, headerSize, func(data []byte) (int64, error) {
+			decoded, err := parseHeader[T](data)
+			if err != nil {
+				return 0, err
+			}
+			return headerSize + int64(8*decoded.size()), nil
+		},
+	)
+}
+
+// parseHeader returns a sketch without counters with the parameters of the header.
+func parseHeader[T any](data []byte) (*CountMinSketch[T], error) {
+	if data[0] != 'C' || data[1] != 'M' {
+		return nil, fmt.Errorf("%w: bad magic", ErrInvalidEncoding)
+	}
+	if data[2] != encodingVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, data[2])
+	}
+	if data[3]&^flagConservative != 0 {
+		return nil, fmt.Errorf("%w: unknown flags %#x", ErrInvalidEncoding, data[3])
+	}
+	depth := uint64(binary.LittleEndian.Uint32(data[4:]))
+	width := binary.LittleEndian.Uint64(data[8:])
+	if depth == 0 || width == 0 || width > 1<<40 || width*depth > 1<<40 {
+		return nil, fmt.Errorf("%w: invalid dimensions %dx%d", ErrInvalidEncoding, depth, width)
+	}
+	return &CountMinSketch[T]{
+		width:        uint(width),
+		depth:        uint(depth),
+		total:        binary.LittleEndian.Uint64(data[16:]),
+		conservative: data[3]&flagConservative != 0,
+	}, nil
+}
+
+func (s *CountMinSketch[T]) size() uint64 {
+	return uint64(s.width) * uint64(s.depth)
+}
@@ -0,0 +1,114 @@
+package countminsketch
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestCountMinSketch_MarshalBinary(t *testing.T) {
+	s := New[string](50, 3, WithConservativeUpdate[string]())
+	s.Add("apple", 5)
+	s.Add("banana", 2)
+
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded CountMinSketch[string]
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if decoded.Width() != 50 || decoded.Depth() != 3 || !decoded.conservative {
+		t.Errorf("Expected a conservative 3x50 sketch, got %+v", decoded)
+	}
+	if decoded.TotalCount() != 7 || decoded.Count("apple") != 5 || decoded.Count("banana") != 2 {
+		t.Errorf("Expected counts to be preserved")
+	}
+}
+
+func TestCountMinSketch_UnmarshalBinaryErrors(t *testing.T) {
+	s := New[int](4, 2)
+	s.Add(1, 3)
+	valid, _ := s.MarshalBinary()
+
+	corrupt := func(f func(data []byte)) []byte {
+		data := append([]byte(nil), valid...)
+		f(data)
+		return data
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"Empty", nil},
+		{"Bad magic", corrupt(func(d []byte) { d[0] = 'X' })},
+		{"Unknown version", corrupt(func(d []byte) { d[2] = 2 })},
+		{"Unknown flags", corrupt(func(d []byte) { d[3] = 2 })},
+		{"Zero depth", corrupt(func(d []byte) { d[4] = 0 })},
+		{"Zero width", corrupt(func(d []byte) { d[8] = 0 })},
+		{"Truncated counters", valid[:len(valid)-1]},
+		{"Row exceeds total", corrupt(func(d []byte) { d[16] = 2 })},
+		{
+			"Row sum overflows", corrupt(
+				func(d []byte) {
+					// The first row wraps around to the total of 3
+					counters := d[headerSize : headerSize+8*4]
+					binary.LittleEndian.PutUint64(counters[0:], math.MaxUint64)
+					binary.LittleEndian.PutUint64(counters[8:], 4)
+					binary.LittleEndian.PutUint64(counters[16:], 0)
+					binary.LittleEndian.PutUint64(counters[24:], 0)
+				},
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				var decoded CountMinSketch[int]
+				if err := decoded.UnmarshalBinary(tt.data); !errors.Is(err, ErrInvalidEncoding) {
+					t.Errorf("Expected ErrInvalidEncoding, got %v", err)
+				}
+			},
+		)
+	}
+}
+
+func TestCountMinSketch_WriteToReadFrom(t *testing.T) {
+	s := New[int](100, 4)
+	for i := 0; i < 100; i++ {
+		s.Add(i, uint64(i))
+	}
+
+	var buf bytes.Buffer
+	written, err := s.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	buf.WriteString("trailer")
+
+	decoded := New[int](1, 1)
+	read, err := decoded.ReadFrom(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if read != written || buf.String() != "trailer" {
+		t.Errorf("Expected to read %d bytes, read %d and left %q", written, read, buf.String())
+	}
+	for i := 0; i < 100; i++ {
+		if decoded.Count(i) != s.Count(i) {
+			t.Fatalf("Count(%d) = %d, want %d", i, decoded.Count(i), s.Count(i))
+		}
+	}
+
+	data, _ := s.MarshalBinary()
+	if _, err := decoded.ReadFrom(bytes.NewReader(data[:len(data)-1])); err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
@@ -0,0 +1,11 @@
+package countminsketch
+
+import "errors"
+
+var (
+	// ErrIncompatible is returned when merging sketches with a different width or depth.
+	ErrIncompatible = errors.New("countminsketch: sketches have different dimensions")
+
+	// ErrInvalidEncoding is returned when decoding data that is not a valid encoded sketch.
+	ErrInvalidEncoding = errors.New("countminsketch: invalid encoding")
+)
@@ -0,0 +1,127 @@
+package countminsketch
+
+import (
+	"sort"
+
+	"github.com/idsulik/go-collections/v3/priorityqueue"
+)
+
+// Entry is an item with its estimated count.
+type Entry[T any] struct {
+	Item  T
+	Count uint64
+}
+
+// TopK tracks the k most frequent items of a stream (heavy hitters). Counts are estimated by a
+// Count-Min sketch with conservative update, and the current top items are kept in a min-heap so
+// that an item replaces the least frequent one as soon as its estimate exceeds it.
+type TopK[T comparable] struct {
+	k      int
+	sketch *CountMinSketch[T]
+	counts map[T]uint64 // Estimated counts of the tracked items
+	heap   *priorityqueue.PriorityQueue[Entry[T]]
+}
+
+// NewTopK creates a tracker of the k most frequent items whose count estimates are within
+// epsilon*TotalCount() with probability 1-delta. Options configure the underlying sketch.
+func NewTopK[T comparable](k int, epsilon, delta float64, opts ...Option[T]) *TopK[T] {
+	if k < 1 {
+		k = 1
+	}
+	opts = append(opts[:len(opts):len(opts)], WithConservativeUpdate[T]())
+	return &TopK[T]{
+		k:      k,
+		sketch: NewWithEstimates[T](epsilon, delta, opts...),
+		counts: make(map[T]uint64, k),
+		heap: priorityqueue.New[Entry[T]](
+			func(a, b Entry[T]) bool {
+				return a.Count < b.Count
+			},
+		),
+	}
+}
+
+// Add increases the count of an item, tracks it if it is now among the top k items,
+// and returns its estimated count.
+func (t *TopK[T]) Add(item T, count uint64) uint64 {
+	estimate := t.sketch.Add(item, count)
+
+	if _, ok := t.counts[item]; !ok && len(t.counts) >= t.k {
+		least := t.least()
+		if estimate <= least.Count {
+			return estimate
+		}
+		t.heap.Pop()
+		delete(t.counts, least.Item)
+	}
+
+	// The heap entry of the previous count, if any, becomes stale and is skipped by least
+	t.counts[item] = estimate
+	t.heap.Push(Entry[T]{Item: item, Count: estimate})
+	if t.heap.Len() > 4*t.k+16 {
+		t.compact()
+	}
+	return estimate
+}
+
+// least returns the tracked item with the smallest count, discarding stale heap entries.
+// It is only called with at least one tracked item.
+func (t *TopK[T]) least() Entry[T] {
+	for {
+		entry, _ := t.heap.Peek()
+		if count, ok := t.counts[entry.Item]; ok && count == entry.Count {
+			return entry
+		}
+		t.heap.Pop()
+	}
+}
+
+// compact rebuilds the heap from the tracked items to drop stale entries.
+func (t *TopK[T]) compact() {
+	t.heap.Clear()
+	for item, count := range t.counts {
+		t.heap.Push(Entry[T]{Item: item, Count: count})
+	}
+}
+
+// List returns the tracked items by decreasing estimated count. Ties are in unspecified order.
+func (t *TopK[T]) List() []Entry[T] {
+	entries := make([]Entry[T], 0, len(t.counts))
+	for item, count := range t.counts {
+		entries = append(entries, Entry[T]{Item: item, Count: count})
+	}
+	sort.Slice(
+		entries, func(i, j int) bool {
+			return entries[i].Count > entries[j].Count
+		},
+	)
+	return entries
+}
+
+// Contains reports whether an item is currently among the top k items.
+func (t *TopK[T]) Contains(item T) bool {
+	_, ok := t.counts[item]
+	return ok
+}
+
+// Count returns the estimated count of an item, whether or not it is among the top k items.
+func (t *TopK[T]) Count(item T) uint64 {
+	return t.sketch.Count(item)
+}
+
+// Len returns the number of tracked items, at most k.
+func (t *TopK[T]) Len() int {
+	return len(t.counts)
+}
+
+// K returns the number of items tracked.
+func (t *TopK[T]) K() int {
+	return t.k
+}
+
+// Clear removes all items and counts.
+func (t *TopK[T]) Clear() {
+	t.sketch.Clear()
+	t.counts = make(map[T]uint64, t.k)
+	t.heap.Clear()
+}
@@ -0,0 +1,82 @@
+package countminsketch
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestTopK(t *testing.T) {
+	topK := NewTopK[int](3, 0.001, 0.01)
+	counts := map[int]uint64{1: 50, 2: 40, 3: 30, 4: 20, 5: 10}
+
+	// Interleave the items so that the top items change while counting
+	for round := uint64(0); round < 50; round++ {
+		for item, count := range counts {
+			if round < count {
+				topK.Add(item, 1)
+			}
+		}
+	}
+
+	list := topK.List()
+	if len(list) != 3 || topK.Len() != 3 {
+		t.Fatalf("Expected 3 items, got %v", list)
+	}
+	for i, expected := range []int{1, 2, 3} {
+		if list[i].Item != expected || list[i].Count != counts[expected] {
+			t.Errorf("Expected entry %d to be %d with count %d, got %+v", i, expected, counts[expected], list[i])
+		}
+	}
+	if !topK.Contains(3) || topK.Contains(4) {
+		t.Error("Expected only the top 3 items to be tracked")
+	}
+	if topK.Count(5) != 10 {
+		t.Errorf("Expected the count of untracked items to be estimated, got %d", topK.Count(5))
+	}
+
+	topK.Clear()
+	if topK.Len() != 0 || len(topK.List()) != 0 || topK.Count(1) != 0 {
+		t.Error("Should be empty after clear")
+	}
+}
+
+func TestTopK_HeavyHitters(t *testing.T) {
+	topK := NewTopK[int](10, 0.0005, 0.01)
+	rng := rand.New(rand.NewSource(1))
+	zipf := rand.NewZipf(rng, 1.2, 1, 100000)
+
+	truth := make(map[int]uint64)
+	for i := 0; i < 200000; i++ {
+		item := int(zipf.Uint64())
+		truth[item]++
+		topK.Add(item, 1)
+	}
+
+	// The most frequent items of a Zipf distribution are the smallest values
+	for _, entry := range topK.List() {
+		if entry.Item >= 10 {
+			t.Errorf("Unexpected heavy hitter %+v", entry)
+		}
+		if entry.Count < truth[entry.Item] {
+			t.Errorf("Estimate %d of item %d is below its true count %d", entry.Count, entry.Item, truth[entry.Item])
+		}
+	}
+	if topK.heap.Len() > 4*topK.K()+16 {
+		t.Errorf("Expected stale heap entries to be compacted, heap has %d entries", topK.heap.Len())
+	}
+}
+
+func BenchmarkTopK(b *testing.B) {
+	topK := NewTopK[int](100, 0.001, 0.01)
+	zipf := rand.NewZipf(rand.New(rand.NewSource(1)), 1.1, 1, 1000000)
+	items := make([]int, 1<<16)
+	for i := range items {
+		items[i] = int(zipf.Uint64())
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		topK.Add(items[i%len(items)], 1)
+	}
+}
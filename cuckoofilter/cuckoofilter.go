@@ -0,0 +1,298 @@
+package cuckoofilter
+
+import (
+	"math"
+
+	"github.com/idsulik/go-collections/v3/bloomfilter"
+)
+
+const (
+	defaultFingerprintBits = 16
+	defaultBucketSize      = 4
+	defaultMaxKicks        = 500
+
+	maxFingerprintBits = 32
+	maxBucketSize      = 16
+	maxLoadFactor      = 0.96
+
+	rngSeed = 0x9e3779b97f4a7c15
+)
+
+// CuckooFilter is an approximate membership structure that supports removal. Each item is
+// represented by a short fingerprint stored in one of two candidate buckets; when both are full,
+// resident fingerprints are moved ("kicked") to their alternate bucket to make room.
+// Compared to a counting Bloom filter, it uses less space for false positive rates below about 3%.
+type CuckooFilter[T any] struct {
+	slots      []uint64 // Fingerprints packed fpBits apart, 0 marks an empty slot
+	numBuckets uint64   // Power of two
+	bucketSize uint
+	fpBits     uint
+	maxKicks   uint
+	count      uint
+	hash       bloomfilter.Hasher[T]
+	rng        uint64
+	kickPath   []uint64
+}
+
+// Option is a function that configures a CuckooFilter.
+type Option[T any] func(*CuckooFilter[T])
+
+// WithFingerprintBits sets the size of the fingerprints, between 2 and 32 bits. Larger fingerprints
+// lower the false positive rate, about 2*bucketSize/2^bits, at the cost of space. The default is 16.
+// Values outside the range are ignored.
+func WithFingerprintBits[T any](bits uint) Option[T] {
+	return func(cf *CuckooFilter[T]) {
+		if bits >= 2 && bits <= maxFingerprintBits {
+			cf.fpBits = bits
+		}
+	}
+}
+
+// WithBucketSize sets the number of fingerprints per bucket, between 1 and 16. Larger buckets allow
+// higher load factors but raise the false positive rate. The default is 4. Values outside the range are ignored.
+func WithBucketSize[T any](size uint) Option[T] {
+	return func(cf *CuckooFilter[T]) {
+		if size >= 1 && size <= maxBucketSize {
+			cf.bucketSize = size
+		}
+	}
+}
+
+// WithMaxKicks sets how many fingerprints Add may relocate before reporting ErrFull. The default is 500.
+func WithMaxKicks[T any](kicks uint) Option[T] {
+	return func(cf *CuckooFilter[T]) {
+		cf.maxKicks = kicks
+	}
+}
+
+// WithHasher sets the function used to hash items.
+func WithHasher[T any](hasher bloomfilter.Hasher[T]) Option[T] {
+	return func(cf *CuckooFilter[T]) {
+		cf.hash = hasher
+	}
+}
+
+// WithEncoder hashes items from the bytes produced by enc.
+func WithEncoder[T any](enc bloomfilter.Encoder[T]) Option[T] {
+	return func(cf *CuckooFilter[T]) {
+		cf.hash = bloomfilter.EncoderHasher(enc)
+	}
+}
+
+// New creates a cuckoo filter able to hold at least capacity items.
+func New[T any](capacity uint, opts ...Option[T]) *CuckooFilter[T] {
+	cf := &CuckooFilter[T]{
+		bucketSize: defaultBucketSize,
+		fpBits:     defaultFingerprintBits,
+		maxKicks:   defaultMaxKicks,
+		hash:       bloomfilter.DefaultHasher[T](),
+	}
+	for _, opt := range opts {
+		opt(cf)
+	}
+
+	if capacity == 0 {
+		capacity = 1
+	}
+	cf.numBuckets = nextPowerOfTwo((uint64(capacity) + uint64(cf.bucketSize) - 1) / uint64(cf.bucketSize))
+	if float64(capacity)/float64(cf.numBuckets*uint64(cf.bucketSize)) > maxLoadFactor {
+		cf.numBuckets *= 2
+	}
+	cf.slots = make([]uint64, cf.wordCount())
+	cf.rng = rngSeed
+	return cf
+}
+
+func nextPowerOfTwo(n uint64) uint64 {
+	p := uint64(1)
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+func (cf *CuckooFilter[T]) numSlots() uint64 {
+	return cf.numBuckets * uint64(cf.bucketSize)
+}
+
+// get returns the fingerprint stored in a slot.
+func (cf *CuckooFilter[T]) get(slot uint64) uint32 {
+	pos := slot * uint64(cf.fpBits)
+	word, offset := pos/64, pos%64
+	v := cf.slots[word] >> offset
+	if offset+uint64(cf.fpBits) > 64 {
+		v |= cf.slots[word+1] << (64 - offset)
+	}
+	return uint32(v & (1<<cf.fpBits - 1))
+}
+
+// set stores a fingerprint in a slot.
+func (cf *CuckooFilter[T]) set(slot uint64, fp uint32) {
+	pos := slot * uint64(cf.fpBits)
+	word, offset := pos/64, pos%64
+	mask := uint64(1)<<cf.fpBits - 1
+	cf.slots[word] = cf.slots[word]&^(mask<<offset) | uint64(fp)<<offset
+	if offset+uint64(cf.fpBits) > 64 {
+		shift := 64 - offset
+		cf.slots[word+1] = cf.slots[word+1]&^(mask>>shift) | uint64(fp)>>shift
+	}
+}
+
+// locate returns the fingerprint and primary bucket of an item.
+func (cf *CuckooFilter[T]) locate(item T) (uint32, uint64) {
+	h1, h2 := cf.hash(item)
+	fp := uint32(h2 >> (64 - cf.fpBits))
+	if fp == 0 {
+		fp = 1
+	}
+	return fp, h1 & (cf.numBuckets - 1)
+}
+
+// altIndex returns the other bucket of a fingerprint. It only depends on the fingerprint and the
+// current bucket, so relocated fingerprints can be moved back without the original item.
+func (cf *CuckooFilter[T]) altIndex(bucket uint64, fp uint32) uint64 {
+	return (bucket ^ uint64(fp)*0x5bd1e995) & (cf.numBuckets - 1)
+}
+
+// insert stores fp in a free slot of the bucket and reports whether there was one.
+func (cf *CuckooFilter[T]) insert(bucket uint64, fp uint32) bool {
+	start := bucket * uint64(cf.bucketSize)
+	for slot := start; slot < start+uint64(cf.bucketSize); slot++ {
+		if cf.get(slot) == 0 {
+			cf.set(slot, fp)
+			return true
+		}
+	}
+	return false
+}
+
+// find returns the slot holding fp in the bucket, or -1.
+func (cf *CuckooFilter[T]) find(bucket uint64, fp uint32) int64 {
+	start := bucket * uint64(cf.bucketSize)
+	for slot := start; slot < start+uint64(cf.bucketSize); slot++ {
+		if cf.get(slot) == fp {
+			return int64(slot)
+		}
+	}
+	return -1
+}
+
+// random returns the next value of a xorshift generator.
+func (cf *CuckooFilter[T]) random() uint64 {
+	cf.rng ^= cf.rng << 13
+	cf.rng ^= cf.rng >> 7
+	cf.rng ^= cf.rng << 17
+	return cf.rng
+}
+
+// Add inserts an item into the filter. If no slot can be freed after relocating up to the
+// configured number of fingerprints, the relocations are undone and ErrFull is returned.
+// Adding the same item more than 2*BucketSize times always fails.
+func (cf *CuckooFilter[T]) Add(item T) error {
+	fp, i1 := cf.locate(item)
+	i2 := cf.altIndex(i1, fp)
+	if cf.insert(i1, fp) || cf.insert(i2, fp) {
+		cf.count++
+		return nil
+	}
+
+	bucket := i1
+	if cf.random()&1 == 1 {
+		bucket = i2
+	}
+	cf.kickPath = cf.kickPath[:0]
+	for n := uint(0); n < cf.maxKicks; n++ {
+		slot := bucket*uint64(cf.bucketSize) + cf.random()%uint64(cf.bucketSize)
+		victim := cf.get(slot)
+		cf.set(slot, fp)
+		cf.kickPath = append(cf.kickPath, slot)
+
+		fp = victim
+		bucket = cf.altIndex(bucket, fp)
+		if cf.insert(bucket, fp) {
+			cf.count++
+			return nil
+		}
+	}
+
+	// Put every relocated fingerprint back where it was
+	for i := len(cf.kickPath) - 1; i >= 0; i-- {
+		slot := cf.kickPath[i]
+		victim := cf.get(slot)
+		cf.set(slot, fp)
+		fp = victim
+	}
+	return ErrFull
+}
+
+// Contains tests whether an item might be in the filter.
+func (cf *CuckooFilter[T]) Contains(item T) bool {
+	fp, i1 := cf.locate(item)
+	return cf.find(i1, fp) >= 0 || cf.find(cf.altIndex(i1, fp), fp) >= 0
+}
+
+// Remove deletes one occurrence of an item and returns true, or returns false if the item is
+// definitely not in the filter. Removing an item that was never added may remove another item
+// with the same fingerprint.
+func (cf *CuckooFilter[T]) Remove(item T) bool {
+	fp, i1 := cf.locate(item)
+	slot := cf.find(i1, fp)
+	if slot < 0 {
+		slot = cf.find(cf.altIndex(i1, fp), fp)
+	}
+	if slot < 0 {
+		return false
+	}
+	cf.set(uint64(slot), 0)
+	cf.count--
+	return true
+}
+
+// EstimatedFalsePositiveRate returns the probability that Contains reports an item that was not
+// added, given the current load: each of the 2*BucketSize candidate slots may hold a matching fingerprint.
+func (cf *CuckooFilter[T]) EstimatedFalsePositiveRate() float64 {
+	if cf.count == 0 {
+		return 0.0
+	}
+	candidates := 2 * float64(cf.bucketSize) * cf.LoadFactor()
+	return 1 - math.Pow(1-1/float64(uint64(1)<<cf.fpBits-1), candidates)
+}
+
+// Clear removes all items from the filter.
+func (cf *CuckooFilter[T]) Clear() {
+	for i := range cf.slots {
+		cf.slots[i] = 0
+	}
+	cf.count = 0
+}
+
+// Len returns the number of items in the filter.
+func (cf *CuckooFilter[T]) Len() int {
+	return int(cf.count)
+}
+
+// IsEmpty returns true if the filter holds no items.
+func (cf *CuckooFilter[T]) IsEmpty() bool {
+	return cf.count == 0
+}
+
+// Capacity returns the number of fingerprint slots. In practice Add starts failing at a load
+// factor of about 95% with the default bucket size.
+func (cf *CuckooFilter[T]) Capacity() uint {
+	return uint(cf.numSlots())
+}
+
+// LoadFactor returns the fraction of occupied slots.
+func (cf *CuckooFilter[T]) LoadFactor() float64 {
+	return float64(cf.count) / float64(cf.numSlots())
+}
+
+// FingerprintBits returns the size of the fingerprints in bits.
+func (cf *CuckooFilter[T]) FingerprintBits() uint {
+	return cf.fpBits
+}
+
+// BucketSize returns the number of fingerprints per bucket.
+func (cf *CuckooFilter[T]) BucketSize() uint {
+	return cf.bucketSize
+}
@@ -0,0 +1,255 @@
+package cuckoofilter
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCuckooFilter_Basic(t *testing.T) {
+	tests := []struct {
+		name          string
+		itemsToAdd    []string
+		itemsToRemove []string
+		itemsToCheck  []string
+		shouldContain []bool
+	}{
+		{
+			name:          "Add only",
+			itemsToAdd:    []string{"apple", "banana", "cherry"},
+			itemsToCheck:  []string{"apple", "banana", "cherry", "date"},
+			shouldContain: []bool{true, true, true, false},
+		},
+		{
+			name:          "Add and remove",
+			itemsToAdd:    []string{"apple", "banana"},
+			itemsToRemove: []string{"apple"},
+			itemsToCheck:  []string{"apple", "banana"},
+			shouldContain: []bool{false, true},
+		},
+		{
+			name:          "Duplicates",
+			itemsToAdd:    []string{"apple", "apple"},
+			itemsToRemove: []string{"apple"},
+			itemsToCheck:  []string{"apple"},
+			shouldContain: []bool{true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				cf := New[string](100)
+				for _, item := range tt.itemsToAdd {
+					if err := cf.Add(item); err != nil {
+						t.Fatalf("Add(%s) failed: %v", item, err)
+					}
+				}
+				for _, item := range tt.itemsToRemove {
+					if !cf.Remove(item) {
+						t.Errorf("Remove(%s) should succeed", item)
+					}
+				}
+
+				if expected := len(tt.itemsToAdd) - len(tt.itemsToRemove); cf.Len() != expected {
+					t.Errorf("Expected length %d, got %d", expected, cf.Len())
+				}
+				for i, item := range tt.itemsToCheck {
+					if cf.Contains(item) != tt.shouldContain[i] {
+						t.Errorf("Contains(%s) = %v, want %v", item, cf.Contains(item), tt.shouldContain[i])
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestCuckooFilter_Options(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            []Option[int]
+		fingerprintBits uint
+		bucketSize      uint
+	}{
+		{"Defaults", nil, 16, 4},
+		{"Custom", []Option[int]{WithFingerprintBits[int](12), WithBucketSize[int](2)}, 12, 2},
+		{"Out of range ignored", []Option[int]{WithFingerprintBits[int](33), WithBucketSize[int](0)}, 16, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				cf := New[int](1000, tt.opts...)
+				if cf.FingerprintBits() != tt.fingerprintBits || cf.BucketSize() != tt.bucketSize {
+					t.Errorf(
+						"Expected %d bits and bucket size %d, got %d and %d",
+						tt.fingerprintBits, tt.bucketSize, cf.FingerprintBits(), cf.BucketSize(),
+					)
+				}
+				if cf.Capacity() < 1000 {
+					t.Errorf("Expected capacity of at least 1000, got %d", cf.Capacity())
+				}
+				for i := 0; i < 1000; i++ {
+					if err := cf.Add(i); err != nil {
+						t.Fatalf("Add(%d) failed: %v", i, err)
+					}
+				}
+				for i := 0; i < 1000; i++ {
+					if !cf.Contains(i) {
+						t.Fatalf("Should contain %d", i)
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestCuckooFilter_Full(t *testing.T) {
+	cf := New[int](1000, WithFingerprintBits[int](20))
+	added := 0
+	var err error
+	for i := 0; err == nil; i++ {
+		if err = cf.Add(i); err == nil {
+			added++
+		}
+	}
+	if err != ErrFull {
+		t.Fatalf("Expected ErrFull, got %v", err)
+	}
+	if added != cf.Len() {
+		t.Errorf("Expected length %d, got %d", added, cf.Len())
+	}
+	if cf.LoadFactor() < 0.9 {
+		t.Errorf("Expected a load factor above 90%% before failing, got %f", cf.LoadFactor())
+	}
+
+	// A failed Add must leave every previously added item in place
+	for i := 0; i < added; i++ {
+		if !cf.Contains(i) {
+			t.Fatalf("Lost item %d after a failed Add", i)
+		}
+	}
+
+	if !cf.Remove(0) {
+		t.Fatal("Remove(0) should succeed")
+	}
+	if err := cf.Add(-1); err != nil {
+		t.Errorf("Expected Add to succeed after a removal, got %v", err)
+	}
+}
+
+func TestCuckooFilter_DuplicateLimit(t *testing.T) {
+	cf := New[string](100, WithBucketSize[string](2))
+	for i := 0; i < 4; i++ {
+		if err := cf.Add("same"); err != nil {
+			t.Fatalf("Add %d failed: %v", i, err)
+		}
+	}
+	if err := cf.Add("same"); err != ErrFull {
+		t.Errorf("Expected ErrFull beyond 2*BucketSize copies, got %v", err)
+	}
+	if cf.Len() != 4 {
+		t.Errorf("Expected length 4, got %d", cf.Len())
+	}
+}
+
+func TestCuckooFilter_FalsePositiveRate(t *testing.T) {
+	tests := []struct {
+		bits    uint
+		maxRate float64
+	}{
+		{8, 0.05},
+		{12, 0.004},
+		{16, 0.0005},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			fmt.Sprintf("%d bits", tt.bits), func(t *testing.T) {
+				cf := New[int](10000, WithFingerprintBits[int](tt.bits))
+				for i := 0; i < 9000; i++ {
+					if err := cf.Add(i); err != nil {
+						t.Fatalf("Add(%d) failed: %v", i, err)
+					}
+				}
+
+				falsePositives := 0
+				trials := 100000
+				for i := 9000; i < 9000+trials; i++ {
+					if cf.Contains(i) {
+						falsePositives++
+					}
+				}
+				actual := float64(falsePositives) / float64(trials)
+				if actual > tt.maxRate {
+					t.Errorf("False positive rate %f exceeds %f", actual, tt.maxRate)
+				}
+				if estimated := cf.EstimatedFalsePositiveRate(); estimated > tt.maxRate {
+					t.Errorf("Estimated false positive rate %f exceeds %f", estimated, tt.maxRate)
+				}
+			},
+		)
+	}
+}
+
+func TestCuckooFilter_RemoveAll(t *testing.T) {
+	cf := New[int](500, WithFingerprintBits[int](7), WithBucketSize[int](3))
+	for i := 0; i < 400; i++ {
+		if err := cf.Add(i); err != nil {
+			t.Fatalf("Add(%d) failed: %v", i, err)
+		}
+	}
+	for i := 0; i < 400; i++ {
+		if !cf.Remove(i) {
+			t.Fatalf("Remove(%d) should succeed", i)
+		}
+	}
+	if !cf.IsEmpty() {
+		t.Errorf("Expected empty filter, got length %d", cf.Len())
+	}
+	for i, word := range cf.slots {
+		if word != 0 {
+			t.Fatalf("Expected all slots to be empty, word %d is %#x", i, word)
+		}
+	}
+
+	cf.Add(1)
+	cf.Clear()
+	if !cf.IsEmpty() || cf.Contains(1) || cf.EstimatedFalsePositiveRate() != 0 {
+		t.Error("Should be empty after clear")
+	}
+}
+
+func BenchmarkCuckooFilter(b *testing.B) {
+	const n = 1 << 16
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("item%d", i)
+	}
+
+	b.Run(
+		"Add", func(b *testing.B) {
+			cf := New[string](n)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if i%(n/2) == 0 {
+					cf.Clear()
+				}
+				_ = cf.Add(keys[i%n])
+			}
+		},
+	)
+
+	b.Run(
+		"Contains", func(b *testing.B) {
+			cf := New[string](n)
+			for _, key := range keys[:n/2] {
+				_ = cf.Add(key)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				cf.Contains(keys[i%n])
+			}
+		},
+	)
+}
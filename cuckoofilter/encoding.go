@@ -0,0 +1,122 @@
+package cuckoofilter
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/idsulik/go-collections/v3/bloomfilter"
+	"github.com/idsulik/go-collections/v3/internal/binenc"
+)
+
+// The binary encoding is a 32-byte header followed by the packed slots as little-endian 64-bit words:
+//
+//	magic "CF" | version | fingerprint bits | bucket size | 3 reserved bytes | numBuckets | count | maxKicks | 4 reserved bytes
+//
+// where numBuckets and count are little-endian uint64 and maxKicks is a little-endian uint32.
+const (
+	encodingVersion = 1
+	headerSize      = 32
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The hasher is not encoded: the decoding side must use the same one.
+func (cf *CuckooFilter[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, headerSize+8*len(cf.slots))
+	data[0], data[1], data[2] = 'C', 'F', encodingVersion
+	data[3], data[4] = byte(cf.fpBits), byte(cf.bucketSize)
+	binary.LittleEndian.PutUint64(data[8:], cf.numBuckets)
+	binary.LittleEndian.PutUint64(data[16:], uint64(cf.count))
+	binary.LittleEndian.PutUint32(data[24:], uint32(cf.maxKicks))
+	for i, word := range cf.slots {
+		binary.LittleEndian.PutUint64(data[headerSize+8*i:], word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It replaces the contents of the filter and keeps its hasher, using the default one if none is set.
+func (cf *CuckooFilter[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize {
+		return fmt.Errorf("%w: data too short", ErrInvalidEncoding)
+	}
+	decoded, err := parseHeader[T](data)
+	if err != nil {
+		return err
+	}
+	if uint64(len(data)-headerSize) != 8*decoded.wordCount() {
+		return fmt.Errorf("%w: expected %d words, got %d bytes", ErrInvalidEncoding, decoded.wordCount(), len(data)-headerSize)
+	}
+
+	decoded.slots = make([]uint64, decoded.wordCount())
+	for i := range decoded.slots {
+		decoded.slots[i] = binary.LittleEndian.Uint64(data[headerSize+8*i:])
+	}
+	if used := decoded.numSlots() * uint64(decoded.fpBits) % 64; used != 0 && decoded.slots[len(decoded.slots)-1]>>used != 0 {
+		return fmt.Errorf("%w: bits set beyond the last slot", ErrInvalidEncoding)
+	}
+	occupied := uint64(0)
+	for slot := uint64(0); slot < decoded.numSlots(); slot++ {
+		if decoded.get(slot) != 0 {
+			occupied++
+		}
+	}
+	if occupied != uint64(decoded.count) {
+		return fmt.Errorf("%w: count %d does not match %d occupied slots", ErrInvalidEncoding, decoded.count, occupied)
+	}
+
+	decoded.hash = cf.hash
+	if decoded.hash == nil {
+		decoded.hash = bloomfilter.DefaultHasher[T]()
+	}
+	*cf = *decoded
+	return nil
+}
+
+// WriteTo implements io.WriterTo, writing the binary encoding of the filter to w.
+func (cf *CuckooFilter[T]) WriteTo(w io.Writer) (int64, error) {
+	return binenc.WriteTo(w, cf)
+}
+
+// ReadFrom implements io.ReaderFrom, reading a filter written by WriteTo from r.
+// It reads exactly the bytes of one encoded filter.
+func (cf *CuckooFilter[T]) ReadFrom(r io.Reader) (int64, error) {
+	return binenc.ReadFrom(
+		r, cf, headerSize, func(data []byte) (int64, error) {
+			decoded, err := parseHeader[T](data)
+			if err != nil {
+				return 0, err
+			}
+			return headerSize + int64(8*decoded.wordCount()), nil
+		},
+	)
+}
+
+// parseHeader returns an empty filter with the parameters of the header.
+func parseHeader[T any](data []byte) (*CuckooFilter[T], error) {
+	if data[0] != 'C' || data[1] != 'F' {
+		return nil, fmt.Errorf("%w: bad magic", ErrInvalidEncoding)
+	}
+	if data[2] != encodingVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, data[2])
+	}
+	cf := &CuckooFilter[T]{
+		fpBits:     uint(data[3]),
+		bucketSize: uint(data[4]),
+		numBuckets: binary.LittleEndian.Uint64(data[8:]),
+		count:      uint(binary.LittleEndian.Uint64(data[16:])),
+		maxKicks:   uint(binary.LittleEndian.Uint32(data[24:])),
+		rng:        rngSeed,
+	}
+	if cf.fpBits < 2 || cf.fpBits > maxFingerprintBits || cf.bucketSize < 1 || cf.bucketSize > maxBucketSize {
+		return nil, fmt.Errorf("%w: invalid parameters", ErrInvalidEncoding)
+	}
+	if cf.numBuckets == 0 || cf.numBuckets&(cf.numBuckets-1) != 0 || cf.numBuckets > 1<<40 {
+		return nil, fmt.Errorf("%w: invalid number of buckets %d", ErrInvalidEncoding, cf.numBuckets)
+	}
+	return cf, nil
+}
+
+func (cf *CuckooFilter[T]) wordCount() uint64 {
+	return (cf.numSlots()*uint64(cf.fpBits) + 63) / 64
+}
@@ -0,0 +1,121 @@
+package cuckoofilter
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestCuckooFilter_MarshalBinary(t *testing.T) {
+	cf := New[int](1000, WithFingerprintBits[int](13), WithBucketSize[int](3), WithMaxKicks[int](50))
+	for i := 0; i < 700; i++ {
+		_ = cf.Add(i)
+	}
+
+	data, err := cf.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded CuckooFilter[int]
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertSameFilter(t, cf, &decoded)
+
+	for i := 0; i < 700; i++ {
+		if !decoded.Contains(i) {
+			t.Fatalf("Decoded filter should contain %d", i)
+		}
+	}
+	if !decoded.Remove(1) || decoded.Len() != cf.Len()-1 {
+		t.Error("Decoded filter should support removal")
+	}
+}
+
+func TestCuckooFilter_UnmarshalBinaryErrors(t *testing.T) {
+	cf := New[int](10, WithFingerprintBits[int](13))
+	_ = cf.Add(1)
+	valid, _ := cf.MarshalBinary()
+
+	corrupt := func(f func(data []byte)) []byte {
+		data := append([]byte(nil), valid...)
+		f(data)
+		return data
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"Empty", nil},
+		{"Short header", valid[:headerSize-1]},
+		{"Bad magic", corrupt(func(d []byte) { d[1] = 'X' })},
+		{"Unknown version", corrupt(func(d []byte) { d[2] = 2 })},
+		{"Fingerprint too large", corrupt(func(d []byte) { d[3] = 33 })},
+		{"Zero bucket size", corrupt(func(d []byte) { d[4] = 0 })},
+		{"Buckets not a power of two", corrupt(func(d []byte) { d[8] = 3 })},
+		{"Truncated slots", valid[:len(valid)-8]},
+		{"Count mismatch", corrupt(func(d []byte) { d[16] = 2 })},
+		{"Bits beyond last slot", corrupt(func(d []byte) { d[len(d)-1] = 0x80 })},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				var decoded CuckooFilter[int]
+				if err := decoded.UnmarshalBinary(tt.data); !errors.Is(err, ErrInvalidEncoding) {
+					t.Errorf("Expected ErrInvalidEncoding, got %v", err)
+				}
+			},
+		)
+	}
+}
+
+func TestCuckooFilter_WriteToReadFrom(t *testing.T) {
+	cf := New[string](100)
+	for _, item := range []string{"apple", "banana", "cherry"} {
+		_ = cf.Add(item)
+	}
+
+	var buf bytes.Buffer
+	written, err := cf.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	buf.WriteString("trailer")
+
+	decoded := New[string](1)
+	read, err := decoded.ReadFrom(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if read != written {
+		t.Errorf("ReadFrom read %d bytes, expected %d", read, written)
+	}
+	if buf.String() != "trailer" {
+		t.Errorf("ReadFrom should not consume bytes past the filter, left %q", buf.String())
+	}
+	assertSameFilter(t, cf, decoded)
+
+	data, _ := cf.MarshalBinary()
+	if _, err := decoded.ReadFrom(bytes.NewReader(data[:len(data)-1])); err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
+
+func assertSameFilter[T any](t *testing.T, expected, actual *CuckooFilter[T]) {
+	t.Helper()
+	if actual.FingerprintBits() != expected.FingerprintBits() || actual.BucketSize() != expected.BucketSize() ||
+		actual.Capacity() != expected.Capacity() || actual.maxKicks != expected.maxKicks {
+		t.Fatalf("Parameters differ: expected %+v, got %+v", expected, actual)
+	}
+	if actual.Len() != expected.Len() {
+		t.Errorf("Expected length %d, got %d", expected.Len(), actual.Len())
+	}
+	for i := range expected.slots {
+		if actual.slots[i] != expected.slots[i] {
+			t.Fatalf("Slots differ at word %d", i)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package cuckoofilter
+
+import "errors"
+
+var (
+	// ErrFull is returned by Add when no slot could be freed for the item within the kick limit.
+	ErrFull = errors.New("cuckoofilter: filter is full")
+
+	// ErrInvalidEncoding is returned when decoding data that is not a valid encoded filter.
+	ErrInvalidEncoding = errors.New("cuckoofilter: invalid encoding")
+)
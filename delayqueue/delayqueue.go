@@ -0,0 +1,237 @@
+package delayqueue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/idsulik/go-collections/v3/priorityqueue"
+)
+
+// Handle identifies a scheduled item so that it can be canceled.
+type Handle uint64
+
+// DelayQueue holds items that only become available once their scheduled time has passed.
+// Items are taken in order of their scheduled time, and items scheduled for the same time in the
+// order they were scheduled. It is safe for concurrent use.
+type DelayQueue[T any] struct {
+	mu    sync.Mutex
+	clock Clock
+	// The heap may still hold canceled entries, which are skipped when they reach the top
+	heap       *priorityqueue.PriorityQueue[*entry[T]]
+	pending    map[Handle]*entry[T]
+	lastHandle Handle
+
+	// changed is closed and replaced to wake up waiting takers when an item becomes the earliest
+	changed chan struct{}
+	takers  int
+}
+
+type entry[T any] struct {
+	item     T
+	at       time.Time
+	handle   Handle
+	canceled bool
+}
+
+// Option is a function that configures a DelayQueue.
+type Option[T any] func(*DelayQueue[T])
+
+// WithClock sets the clock used to decide when items are due. It defaults to SystemClock().
+func WithClock[T any](clock Clock) Option[T] {
+	return func(q *DelayQueue[T]) {
+		q.clock = clock
+	}
+}
+
+// New creates a new, empty DelayQueue.
+func New[T any](opts ...Option[T]) *DelayQueue[T] {
+	q := &DelayQueue[T]{
+		clock: SystemClock(),
+		heap: priorityqueue.New(
+			func(a, b *entry[T]) bool {
+				if a.at.Equal(b.at) {
+					return a.handle < b.handle
+				}
+				return a.at.Before(b.at)
+			},
+		),
+		pending: make(map[Handle]*entry[T]),
+		changed: make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+// Schedule adds an item that becomes available at the given time, and returns a handle to cancel it.
+// Times in the past make the item available immediately.
+func (q *DelayQueue[T]) Schedule(item T, at time.Time) Handle {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.lastHandle++
+	e := &entry[T]{item: item, at: at, handle: q.lastHandle}
+	q.heap.Push(e)
+	q.pending[e.handle] = e
+
+	if q.peek() == e {
+		q.notify()
+	}
+	return e.handle
+}
+
+// ScheduleAfter adds an item that becomes available after the delay d, measured by the queue's clock.
+func (q *DelayQueue[T]) ScheduleAfter(item T, d time.Duration) Handle {
+	return q.Schedule(item, q.clock.Now().Add(d))
+}
+
+// Cancel removes the item with the given handle. It returns false if the item was already taken or canceled.
+func (q *DelayQueue[T]) Cancel(h Handle) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	e, ok := q.pending[h]
+	if !ok {
+		return false
+	}
+	wasHead := q.peek() == e
+	delete(q.pending, h)
+	var zero T
+	e.item = zero
+	e.canceled = true
+
+	// Rebuild the heap once canceled entries dominate it
+	if q.heap.Len() > 2*len(q.pending)+16 {
+		q.heap.Clear()
+		for _, e := range q.pending {
+			q.heap.Push(e)
+		}
+	}
+
+	// Takers waiting for the canceled item must wait for the next one instead
+	if wasHead {
+		q.notify()
+	}
+	return true
+}
+
+// Take removes and returns the earliest item, waiting until it is due. Items scheduled while
+// waiting are taken into account. It returns ctx.Err() if ctx is done before an item is due.
+func (q *DelayQueue[T]) Take(ctx context.Context) (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for {
+		var timer Timer
+		var timerC <-chan time.Time
+		if e := q.peek(); e != nil {
+			d := e.at.Sub(q.clock.Now())
+			if d <= 0 {
+				return q.pop(), nil
+			}
+			timer = q.clock.NewTimer(d)
+			timerC = timer.C()
+		}
+
+		changed := q.changed
+		q.takers++
+		q.mu.Unlock()
+		var err error
+		select {
+		case <-changed:
+		case <-timerC:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		q.mu.Lock()
+		q.takers--
+
+		if timer != nil {
+			timer.Stop()
+		}
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+}
+
+// Poll removes and returns the earliest item if it is due, without waiting.
+// Returns false if no item is due.
+func (q *DelayQueue[T]) Poll() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	e := q.peek()
+	if e == nil || e.at.After(q.clock.Now()) {
+		var zero T
+		return zero, false
+	}
+	return q.pop(), true
+}
+
+// Peek returns the earliest item and the time it becomes available, whether or not it is due,
+// without removing it. Returns false if the queue is empty.
+func (q *DelayQueue[T]) Peek() (T, time.Time, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	e := q.peek()
+	if e == nil {
+		var zero T
+		return zero, time.Time{}, false
+	}
+	return e.item, e.at, true
+}
+
+// Len returns the number of scheduled items, whether or not they are due.
+func (q *DelayQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// IsEmpty checks if the queue has no scheduled items.
+func (q *DelayQueue[T]) IsEmpty() bool {
+	return q.Len() == 0
+}
+
+// Clear removes all scheduled items. Their handles can no longer be canceled.
+func (q *DelayQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.heap.Clear()
+	q.pending = make(map[Handle]*entry[T])
+	q.notify()
+}
+
+// notify wakes up waiting takers so that they wait for the earliest item again.
+func (q *DelayQueue[T]) notify() {
+	if q.takers > 0 {
+		close(q.changed)
+		q.changed = make(chan struct{})
+	}
+}
+
+// peek returns the earliest entry that is not canceled, dropping canceled ones from the top of the heap.
+func (q *DelayQueue[T]) peek() *entry[T] {
+	for {
+		e, ok := q.heap.Peek()
+		if !ok {
+			return nil
+		}
+		if !e.canceled {
+			return e
+		}
+		q.heap.Pop()
+	}
+}
+
+// pop removes the entry at the top of the heap, which must have been returned by peek.
+func (q *DelayQueue[T]) pop() T {
+	e, _ := q.heap.Pop()
+	delete(q.pending, e.handle)
+	return e.item
+}
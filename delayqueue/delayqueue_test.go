@@ -0,0 +1,386 @@
+package delayqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock whose time only moves when Advance is called.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock *fakeClock
+	at    time.Time
+	c     chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, at: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.c <- c.now
+	} else {
+		c.timers = append(c.timers, t)
+	}
+	return t
+}
+
+// Advance moves the time forward and fires the timers that are due.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	active := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			active = append(active, t)
+		} else {
+			t.c <- c.now
+		}
+	}
+	c.timers = active
+}
+
+// WaitForTimer waits until a timer firing at the given time is active, so that a Take is known
+// to be waiting for it.
+func (c *fakeClock) WaitForTimer(t *testing.T, at time.Time) {
+	deadline := time.Now().Add(time.Second)
+	for !c.hasTimer(at) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for a timer at %v", at)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (c *fakeClock) hasTimer(at time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, t := range c.timers {
+		if t.at.Equal(at) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	for i, other := range t.clock.timers {
+		if other == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func TestDelayQueue(t *testing.T) {
+	t.Run(
+		"New queue is empty", func(t *testing.T) {
+			q := New[int]()
+			if !q.IsEmpty() || q.Len() != 0 {
+				t.Error("New queue should be empty")
+			}
+			if _, ok := q.Poll(); ok {
+				t.Error("Poll should fail on an empty queue")
+			}
+			if _, _, ok := q.Peek(); ok {
+				t.Error("Peek should fail on an empty queue")
+			}
+		},
+	)
+
+	t.Run(
+		"Items become available at their time", func(t *testing.T) {
+			clock := newFakeClock()
+			q := New[string](WithClock[string](clock))
+			q.ScheduleAfter("b", 2*time.Second)
+			q.ScheduleAfter("a", time.Second)
+
+			if _, ok := q.Poll(); ok {
+				t.Error("Poll should fail before any item is due")
+			}
+			item, at, ok := q.Peek()
+			if !ok || item != "a" || !at.Equal(clock.Now().Add(time.Second)) {
+				t.Errorf("Peek() = %q, %v, %v; want \"a\" due in 1s", item, at, ok)
+			}
+
+			clock.Advance(time.Second)
+			if item, ok := q.Poll(); !ok || item != "a" {
+				t.Errorf("Poll() = %q, %v; want \"a\", true", item, ok)
+			}
+			if _, ok := q.Poll(); ok {
+				t.Error("Poll should fail while \"b\" is not due")
+			}
+
+			clock.Advance(time.Second)
+			if item, ok := q.Poll(); !ok || item != "b" {
+				t.Errorf("Poll() = %q, %v; want \"b\", true", item, ok)
+			}
+		},
+	)
+
+	t.Run(
+		"Same time keeps scheduling order", func(t *testing.T) {
+			clock := newFakeClock()
+			q := New[int](WithClock[int](clock))
+			at := clock.Now()
+			for i := 0; i < 5; i++ {
+				q.Schedule(i, at)
+			}
+			for i := 0; i < 5; i++ {
+				if item, ok := q.Poll(); !ok || item != i {
+					t.Errorf("Poll() = %d, %v; want %d, true", item, ok, i)
+				}
+			}
+		},
+	)
+
+	t.Run(
+		"Cancel", func(t *testing.T) {
+			clock := newFakeClock()
+			q := New[int](WithClock[int](clock))
+			h1 := q.ScheduleAfter(1, time.Second)
+			q.ScheduleAfter(2, 2*time.Second)
+
+			if !q.Cancel(h1) {
+				t.Error("Cancel should succeed for a scheduled item")
+			}
+			if q.Cancel(h1) {
+				t.Error("Cancel should fail for a canceled item")
+			}
+			if q.Len() != 1 {
+				t.Errorf("Expected length 1, got %d", q.Len())
+			}
+
+			clock.Advance(2 * time.Second)
+			if item, ok := q.Poll(); !ok || item != 2 {
+				t.Errorf("Poll() = %d, %v; want 2, true", item, ok)
+			}
+		},
+	)
+
+	t.Run(
+		"Cancel compacts the heap", func(t *testing.T) {
+			clock := newFakeClock()
+			q := New[int](WithClock[int](clock))
+			handles := make([]Handle, 1000)
+			for i := range handles {
+				handles[i] = q.ScheduleAfter(i, time.Duration(i+1)*time.Second)
+			}
+			for _, h := range handles[:990] {
+				q.Cancel(h)
+			}
+			if q.heap.Len() > 2*q.Len()+16 {
+				t.Errorf("Heap holds %d entries for %d items", q.heap.Len(), q.Len())
+			}
+
+			clock.Advance(time.Hour)
+			for i := 990; i < 1000; i++ {
+				if item, ok := q.Poll(); !ok || item != i {
+					t.Fatalf("Poll() = %d, %v; want %d, true", item, ok, i)
+				}
+			}
+		},
+	)
+
+	t.Run(
+		"Clear", func(t *testing.T) {
+			q := New[int]()
+			h := q.ScheduleAfter(1, 0)
+			q.Clear()
+			if !q.IsEmpty() {
+				t.Error("Queue should be empty after Clear")
+			}
+			if q.Cancel(h) {
+				t.Error("Cancel should fail after Clear")
+			}
+		},
+	)
+}
+
+func TestDelayQueue_Take(t *testing.T) {
+	t.Run(
+		"Take waits until the item is due", func(t *testing.T) {
+			clock := newFakeClock()
+			q := New[int](WithClock[int](clock))
+			q.ScheduleAfter(1, time.Minute)
+
+			result := make(chan int)
+			go func() {
+				item, _ := q.Take(context.Background())
+				result <- item
+			}()
+
+			clock.WaitForTimer(t, clock.Now().Add(time.Minute))
+			clock.Advance(30 * time.Second)
+			select {
+			case <-result:
+				t.Fatal("Take returned before the item was due")
+			case <-time.After(5 * time.Millisecond):
+			}
+
+			clock.Advance(30 * time.Second)
+			if item := <-result; item != 1 {
+				t.Errorf("Take() = %d, want 1", item)
+			}
+		},
+	)
+
+	t.Run(
+		"Take wakes up for an earlier item", func(t *testing.T) {
+			clock := newFakeClock()
+			q := New[int](WithClock[int](clock))
+			q.ScheduleAfter(1, time.Hour)
+
+			result := make(chan int)
+			go func() {
+				item, _ := q.Take(context.Background())
+				result <- item
+			}()
+
+			clock.WaitForTimer(t, clock.Now().Add(time.Hour))
+			q.ScheduleAfter(2, time.Second)
+			clock.WaitForTimer(t, clock.Now().Add(time.Second))
+			clock.Advance(time.Second)
+			if item := <-result; item != 2 {
+				t.Errorf("Take() = %d, want 2", item)
+			}
+		},
+	)
+
+	t.Run(
+		"Take waits for the next item when the earliest is canceled", func(t *testing.T) {
+			clock := newFakeClock()
+			q := New[int](WithClock[int](clock))
+			start := clock.Now()
+			h := q.ScheduleAfter(1, time.Second)
+			q.ScheduleAfter(2, time.Hour)
+
+			result := make(chan int)
+			go func() {
+				item, _ := q.Take(context.Background())
+				result <- item
+			}()
+
+			clock.WaitForTimer(t, start.Add(time.Second))
+			q.Cancel(h)
+			clock.WaitForTimer(t, start.Add(time.Hour))
+			if clock.hasTimer(start.Add(time.Second)) {
+				t.Error("Expected the timer for the canceled item to be stopped")
+			}
+			clock.Advance(time.Hour)
+			if item := <-result; item != 2 {
+				t.Errorf("Take() = %d, want 2", item)
+			}
+		},
+	)
+
+	t.Run(
+		"Take on empty queue waits for Schedule", func(t *testing.T) {
+			q := New[int]()
+			result := make(chan int)
+			go func() {
+				item, _ := q.Take(context.Background())
+				result <- item
+			}()
+
+			time.Sleep(5 * time.Millisecond)
+			q.Schedule(7, time.Now())
+			if item := <-result; item != 7 {
+				t.Errorf("Take() = %d, want 7", item)
+			}
+		},
+	)
+
+	t.Run(
+		"Take skips canceled items", func(t *testing.T) {
+			clock := newFakeClock()
+			q := New[int](WithClock[int](clock))
+			h := q.ScheduleAfter(1, time.Second)
+			q.ScheduleAfter(2, 2*time.Second)
+			q.Cancel(h)
+
+			clock.Advance(2 * time.Second)
+			item, err := q.Take(context.Background())
+			if err != nil || item != 2 {
+				t.Errorf("Take() = %d, %v; want 2, nil", item, err)
+			}
+		},
+	)
+
+	t.Run(
+		"Take returns when the context is done", func(t *testing.T) {
+			q := New[int]()
+			q.ScheduleAfter(1, time.Hour)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			if _, err := q.Take(ctx); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Take() = %v, want context.DeadlineExceeded", err)
+			}
+			if q.Len() != 1 {
+				t.Errorf("Expected the item to stay scheduled, got length %d", q.Len())
+			}
+		},
+	)
+
+	t.Run(
+		"Concurrent takers", func(t *testing.T) {
+			q := New[int]()
+			const n = 100
+			now := time.Now()
+			for i := 0; i < n; i++ {
+				q.Schedule(i, now.Add(time.Duration(i%10)*time.Millisecond))
+			}
+
+			var mu sync.Mutex
+			seen := make(map[int]bool)
+			var wg sync.WaitGroup
+			for w := 0; w < 4; w++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for {
+						ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+						item, err := q.Take(ctx)
+						cancel()
+						if err != nil {
+							return
+						}
+						mu.Lock()
+						seen[item] = true
+						mu.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+			if len(seen) != n {
+				t.Errorf("Expected %d distinct items, got %d", n, len(seen))
+			}
+		},
+	)
+}
@@ -3,12 +3,14 @@ package deque
 const (
 	defaultCapacity = 16 // Default initial capacity for the deque
 	resizeFactor    = 2  // Factor by which the deque is resized when full
+	shrinkDivisor   = 4  // The deque shrinks when at most 1/shrinkDivisor of its capacity is used
 )
 
 type Deque[T any] struct {
 	buffer         []T // Underlying slice to hold elements
 	head, tail     int // Indices for the front and back of the deque
 	size, capacity int // Current size and maximum capacity of the deque
+	minCapacity    int // Capacity below which the deque does not shrink
 }
 
 // New creates a new Deque with the specified initial capacity.
@@ -19,8 +21,9 @@ func New[T any](initialCapacity int) *Deque[T] {
 	}
 
 	return &Deque[T]{
-		buffer:   make([]T, initialCapacity),
-		capacity: initialCapacity,
+		buffer:      make([]T, initialCapacity),
+		capacity:    initialCapacity,
+		minCapacity: initialCapacity,
 	}
 }
 
@@ -30,6 +33,19 @@ func (d *Deque[T]) resize() {
 	d.reallocate(newCapacity)
 }
 
+// shrink halves the capacity of the deque when it is sparsely used, so that memory is released
+// after a burst of items has been removed. It never shrinks below the initial capacity.
+func (d *Deque[T]) shrink() {
+	if d.capacity <= d.minCapacity || d.size > d.capacity/shrinkDivisor {
+		return
+	}
+	newCapacity := d.capacity / resizeFactor
+	if newCapacity < d.minCapacity {
+		newCapacity = d.minCapacity
+	}
+	d.reallocate(newCapacity)
+}
+
 // reallocate creates a new buffer with the specified capacity and copies elements.
 func (d *Deque[T]) reallocate(newCapacity int) {
 	newBuffer := make([]T, newCapacity)
@@ -84,6 +100,7 @@ func (d *Deque[T]) PopFront() (T, bool) {
 	d.buffer[d.head] = zero // Clear reference
 	d.head = (d.head + 1) % d.capacity
 	d.size--
+	d.shrink()
 
 	return item, true
 }
@@ -104,6 +121,7 @@ func (d *Deque[T]) PopBack() (T, bool) {
 	var zero T
 	d.buffer[d.tail] = zero // Clear reference
 	d.size--
+	d.shrink()
 
 	return item, true
 }
@@ -185,11 +203,12 @@ func (d *Deque[T]) GetItems() []T {
 // Clone returns a deep copy of the deque.
 func (d *Deque[T]) Clone() *Deque[T] {
 	newDeque := &Deque[T]{
-		buffer:   make([]T, d.capacity),
-		head:     d.head,
-		tail:     d.tail,
-		size:     d.size,
-		capacity: d.capacity,
+		buffer:      make([]T, d.capacity),
+		head:        d.head,
+		tail:        d.tail,
+		size:        d.size,
+		capacity:    d.capacity,
+		minCapacity: d.minCapacity,
 	}
 	copy(newDeque.buffer, d.buffer)
 	return newDeque
@@ -168,6 +168,47 @@ func TestCapacity(t *testing.T) {
 			}
 		},
 	)
+
+	t.Run(
+		"capacity shrinks on low occupancy", func(t *testing.T) {
+			d := New[int](4)
+			for i := 0; i < 1000; i++ {
+				d.PushBack(i)
+			}
+			grownCap := d.Cap()
+
+			// Remove from both ends so that the buffer wraps while shrinking
+			for i := 0; i < 495; i++ {
+				d.PopFront()
+				d.PopBack()
+			}
+			if got := d.Cap(); got >= grownCap/4 {
+				t.Errorf("Cap() after removals = %d; want less than %d", got, grownCap/4)
+			}
+			expected := []int{495, 496, 497, 498, 499, 500, 501, 502, 503, 504}
+			if got := d.GetItems(); !slices.Equal(got, expected) {
+				t.Errorf("GetItems() = %v; want %v", got, expected)
+			}
+
+			for !d.IsEmpty() {
+				d.PopFront()
+			}
+			if got := d.Cap(); got != 4 {
+				t.Errorf("Cap() when empty = %d; want initial capacity 4", got)
+			}
+		},
+	)
+
+	t.Run(
+		"no shrink below initial capacity", func(t *testing.T) {
+			d := New[int](64)
+			d.PushBack(1)
+			d.PopFront()
+			if got := d.Cap(); got != 64 {
+				t.Errorf("Cap() = %d; want 64", got)
+			}
+		},
+	)
 }
 
 func TestGetItems(t *testing.T) {
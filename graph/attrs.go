@@ -0,0 +1,69 @@
+package graph
+
+// Attr is a typed key for values attached to nodes and edges. Values stored under an Attr can
+// only be read back as a V, so no type assertions are needed. Attributes are compared by identity:
+// two calls to NewAttr create different attributes even if they have the same name.
+type Attr[V any] struct {
+	key *attrKey
+}
+
+type attrKey struct {
+	name string
+}
+
+// NewAttr creates a new attribute. The name is only used to describe it.
+func NewAttr[V any](name string) Attr[V] {
+	return Attr[V]{key: &attrKey{name: name}}
+}
+
+// Name returns the name the attribute was created with.
+func (a Attr[V]) Name() string {
+	return a.key.name
+}
+
+// Get returns the value of the attribute in attrs. The second return value is false if it is not set.
+func (a Attr[V]) Get(attrs Attrs) (V, bool) {
+	value, exists := attrs.values[a.key]
+	if !exists {
+		var zero V
+		return zero, false
+	}
+	return value.(V), true
+}
+
+// With returns the attribute set to value, to be passed to AddNodeWith, AddEdgeWith or the Set...Attrs methods.
+func (a Attr[V]) With(value V) AttrValue {
+	return AttrValue{key: a.key, value: value}
+}
+
+// AttrValue is an attribute together with a value for it, created with Attr.With.
+type AttrValue struct {
+	key   *attrKey
+	value any
+}
+
+// Attrs holds the attributes of a node or edge. Read them with Attr.Get.
+// An Attrs is never modified once created, so it is a snapshot that can be kept and shared.
+type Attrs struct {
+	values map[*attrKey]any
+}
+
+// Len returns the number of attributes that are set.
+func (a Attrs) Len() int {
+	return len(a.values)
+}
+
+// with returns a copy of a with the given attributes set. It returns a itself if there are none.
+func (a Attrs) with(values []AttrValue) Attrs {
+	if len(values) == 0 {
+		return a
+	}
+	m := make(map[*attrKey]any, len(a.values)+len(values))
+	for k, v := range a.values {
+		m[k] = v
+	}
+	for _, v := range values {
+		m[v.key] = v.value
+	}
+	return Attrs{values: m}
+}
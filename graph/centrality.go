@@ -0,0 +1,331 @@
+package graph
+
+import "math"
+
+// PageRankOption configures PageRank.
+type PageRankOption[T comparable] func(*pageRank[T])
+
+type pageRank[T comparable] struct {
+	damping         float64
+	tolerance       float64
+	maxIterations   int
+	personalization map[T]float64
+}
+
+// WithDamping sets the probability of following an edge rather than teleporting. The default is 0.85.
+func WithDamping[T comparable](damping float64) PageRankOption[T] {
+	return func(pr *pageRank[T]) {
+		pr.damping = damping
+	}
+}
+
+// WithTolerance sets the per-node convergence threshold. The default is 1e-6.
+func WithTolerance[T comparable](tolerance float64) PageRankOption[T] {
+	return func(pr *pageRank[T]) {
+		pr.tolerance = tolerance
+	}
+}
+
+// WithMaxIterations sets the maximum number of power iterations. The default is 100.
+func WithMaxIterations[T comparable](iterations int) PageRankOption[T] {
+	return func(pr *pageRank[T]) {
+		pr.maxIterations = iterations
+	}
+}
+
+// WithPersonalization sets the teleport distribution. Nodes missing from the map get zero weight
+// and the values are normalized to sum to one. The default, also used when all values are zero,
+// is uniform over all nodes.
+func WithPersonalization[T comparable](personalization map[T]float64) PageRankOption[T] {
+	return func(pr *pageRank[T]) {
+		pr.personalization = personalization
+	}
+}
+
+// PageRank ranks the nodes by the stationary distribution of a random walk that follows
+// edges proportionally to their weight. Undirected edges are followed in both directions
+// and the rank of nodes without outgoing edges is redistributed by the personalization vector.
+// If the iteration limit is reached, the last ranks are returned together with ErrNoConvergence.
+func (g *Graph[T]) PageRank(opts ...PageRankOption[T]) (map[T]float64, error) {
+	return g.PageRankFunc(EdgeWeight[T], opts...)
+}
+
+// PageRankFunc is like PageRank but reads edge weights with the given WeightFunc.
+func (g *Graph[T]) PageRankFunc(weight WeightFunc[T], opts ...PageRankOption[T]) (map[T]float64, error) {
+	return g.frozen().PageRankFunc(weight, opts...)
+}
+
+// PageRank ranks the nodes by the stationary distribution of a random walk.
+// See Graph.PageRank for details.
+func (f *Frozen[T]) PageRank(opts ...PageRankOption[T]) (map[T]float64, error) {
+	return f.PageRankFunc(EdgeWeight[T], opts...)
+}
+
+// PageRankFunc is like PageRank but reads edge weights with the given WeightFunc.
+func (f *Frozen[T]) PageRankFunc(weight WeightFunc[T], opts ...PageRankOption[T]) (map[T]float64, error) {
+	pr := &pageRank[T]{damping: 0.85, tolerance: 1e-6, maxIterations: 100}
+	for _, opt := range opts {
+		opt(pr)
+	}
+
+	n := len(f.values)
+	ranks := make(map[T]float64, n)
+	if n == 0 {
+		return ranks, nil
+	}
+
+	teleport := make([]float64, n)
+	total := 0.0
+	for v, p := range pr.personalization {
+		i, exists := f.index[v]
+		if !exists {
+			return nil, ErrNodeNotFound
+		}
+		if p < 0 {
+			return nil, ErrNegativeWeight
+		}
+		teleport[i] = p
+		total += p
+	}
+	for i := range teleport {
+		if total > 0 {
+			teleport[i] /= total
+		} else {
+			teleport[i] = 1 / float64(n)
+		}
+	}
+
+	// Transition probabilities: edge weights normalized by the total outgoing weight of each node
+	transition, err := f.arcWeights(weight)
+	if err != nil {
+		return nil, err
+	}
+	dangling := make([]bool, n)
+	for u := 0; u < n; u++ {
+		total := 0.0
+		for a := f.offsets[u]; a < f.offsets[u+1]; a++ {
+			total += transition[a]
+		}
+		if total == 0 {
+			dangling[u] = true
+			continue
+		}
+		for a := f.offsets[u]; a < f.offsets[u+1]; a++ {
+			transition[a] /= total
+		}
+	}
+
+	x := append([]float64(nil), teleport...)
+	next := make([]float64, n)
+	err = ErrNoConvergence
+	for iter := 0; iter < pr.maxIterations; iter++ {
+		danglingRank := 0.0
+		for i := range next {
+			next[i] = 0
+			if dangling[i] {
+				danglingRank += x[i]
+			}
+		}
+		for u := 0; u < n; u++ {
+			for a := f.offsets[u]; a < f.offsets[u+1]; a++ {
+				next[f.targets[a]] += pr.damping * x[u] * transition[a]
+			}
+		}
+
+		diff := 0.0
+		for i := range next {
+			next[i] += (pr.damping*danglingRank + 1 - pr.damping) * teleport[i]
+			diff += math.Abs(next[i] - x[i])
+		}
+		x, next = next, x
+
+		if diff < float64(n)*pr.tolerance {
+			err = nil
+			break
+		}
+	}
+
+	for i, v := range f.values {
+		ranks[v] = x[i]
+	}
+	return ranks, err
+}
+
+// DegreeCentrality returns the fraction of other nodes each node is connected to.
+// For directed graphs the in-degree and out-degree are added up. Parallel edges count
+// separately and an undirected self-loop adds two to the degree.
+func (g *Graph[T]) DegreeCentrality() map[T]float64 {
+	return g.degreeCentrality(true, true)
+}
+
+// InDegreeCentrality returns the in-degree of each node divided by the number of other nodes.
+// For undirected graphs it equals DegreeCentrality.
+func (g *Graph[T]) InDegreeCentrality() map[T]float64 {
+	return g.degreeCentrality(true, false)
+}
+
+// OutDegreeCentrality returns the out-degree of each node divided by the number of other nodes.
+// For undirected graphs it equals DegreeCentrality.
+func (g *Graph[T]) OutDegreeCentrality() map[T]float64 {
+	return g.degreeCentrality(false, true)
+}
+
+func (g *Graph[T]) degreeCentrality(in, out bool) map[T]float64 {
+	return g.frozen().degreeCentrality(in, out)
+}
+
+// DegreeCentrality returns the fraction of other nodes each node is connected to.
+// See Graph.DegreeCentrality for details.
+func (f *Frozen[T]) DegreeCentrality() map[T]float64 {
+	return f.degreeCentrality(true, true)
+}
+
+// InDegreeCentrality returns the in-degree of each node divided by the number of other nodes.
+func (f *Frozen[T]) InDegreeCentrality() map[T]float64 {
+	return f.degreeCentrality(true, false)
+}
+
+// OutDegreeCentrality returns the out-degree of each node divided by the number of other nodes.
+func (f *Frozen[T]) OutDegreeCentrality() map[T]float64 {
+	return f.degreeCentrality(false, true)
+}
+
+func (f *Frozen[T]) degreeCentrality(in, out bool) map[T]float64 {
+	n := len(f.values)
+	centrality := make(map[T]float64, n)
+	if n == 1 {
+		centrality[f.values[0]] = 1
+		return centrality
+	}
+
+	scale := 1 / float64(n-1)
+	for u, v := range f.values {
+		degree := 0
+		if out || !f.directed {
+			degree += f.offsets[u+1] - f.offsets[u]
+		}
+		if in && f.directed {
+			degree += f.inOffsets[u+1] - f.inOffsets[u]
+		}
+		if !f.directed {
+			// Self-loops are stored once but count twice
+			for a := f.offsets[u]; a < f.offsets[u+1]; a++ {
+				if f.targets[a] == u {
+					degree++
+				}
+			}
+		}
+		centrality[v] = float64(degree) * scale
+	}
+	return centrality
+}
+
+// ClosenessCentrality returns, for every node, the inverse of its average shortest path distance
+// to the nodes it can reach, using edge weights as distances. The value is scaled by the fraction
+// of nodes reachable, so nodes in small components score lower (Wasserman and Faust).
+// A node that reaches other nodes, all at distance zero, is infinitely close and scores +Inf;
+// a node that reaches no other node scores 0.
+// For directed graphs the distances from the node along outgoing edges are used.
+func (g *Graph[T]) ClosenessCentrality() (map[T]float64, error) {
+	return g.ClosenessCentralityFunc(EdgeWeight[T])
+}
+
+// ClosenessCentralityFunc is like ClosenessCentrality but reads distances with the given WeightFunc.
+func (g *Graph[T]) ClosenessCentralityFunc(weight WeightFunc[T]) (map[T]float64, error) {
+	return g.frozen().ClosenessCentralityFunc(weight)
+}
+
+// ClosenessCentrality returns the inverse average shortest path distance of every node.
+// See Graph.ClosenessCentrality for details.
+func (f *Frozen[T]) ClosenessCentrality() (map[T]float64, error) {
+	return f.ClosenessCentralityFunc(EdgeWeight[T])
+}
+
+// ClosenessCentralityFunc is like ClosenessCentrality but reads distances with the given WeightFunc.
+func (f *Frozen[T]) ClosenessCentralityFunc(weight WeightFunc[T]) (map[T]float64, error) {
+	weights, err := f.arcWeights(weight)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(f.values)
+	centrality := make(map[T]float64, n)
+	for s, v := range f.values {
+		tree := f.dijkstra(weights, nil, s)
+		total := 0.0
+		for _, u := range tree.order {
+			total += tree.dist[u]
+		}
+
+		reached := float64(len(tree.order) - 1)
+		switch {
+		case reached == 0:
+			centrality[v] = 0
+		case total == 0:
+			centrality[v] = math.Inf(1) // Every reachable node is at distance zero
+		default:
+			centrality[v] = (reached / total) * (reached / float64(n-1))
+		}
+	}
+	return centrality, nil
+}
+
+// BetweennessCentrality returns, for every node, the fraction of shortest paths between other
+// pairs of nodes that pass through it, using Brandes' algorithm with edge weights as distances.
+// If normalized is true the values are divided by the number of pairs not involving the node.
+func (g *Graph[T]) BetweennessCentrality(normalized bool) (map[T]float64, error) {
+	return g.BetweennessCentralityFunc(normalized, EdgeWeight[T])
+}
+
+// BetweennessCentralityFunc is like BetweennessCentrality but reads distances with the given WeightFunc.
+func (g *Graph[T]) BetweennessCentralityFunc(normalized bool, weight WeightFunc[T]) (map[T]float64, error) {
+	return g.frozen().BetweennessCentralityFunc(normalized, weight)
+}
+
+// BetweennessCentrality returns the fraction of shortest paths passing through every node.
+// See Graph.BetweennessCentrality for details.
+func (f *Frozen[T]) BetweennessCentrality(normalized bool) (map[T]float64, error) {
+	return f.BetweennessCentralityFunc(normalized, EdgeWeight[T])
+}
+
+// BetweennessCentralityFunc is like BetweennessCentrality but reads distances with the given WeightFunc.
+func (f *Frozen[T]) BetweennessCentralityFunc(normalized bool, weight WeightFunc[T]) (map[T]float64, error) {
+	weights, err := f.arcWeights(weight)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(f.values)
+	betweenness := make([]float64, n)
+	delta := make([]float64, n)
+	for s := range f.values {
+		tree := f.dijkstra(weights, nil, s)
+		for i := range delta {
+			delta[i] = 0
+		}
+		// Accumulate dependencies in order of non-increasing distance from s
+		for k := len(tree.order) - 1; k >= 0; k-- {
+			w := tree.order[k]
+			for _, v := range tree.preds[w] {
+				delta[v] += tree.sigma[v] / tree.sigma[w] * (1 + delta[w])
+			}
+			if w != s {
+				betweenness[w] += delta[w]
+			}
+		}
+	}
+
+	scale := 1.0
+	if normalized && n > 2 {
+		// Undirected paths are counted from both ends, matching the number of ordered pairs
+		scale = 1 / float64((n-1)*(n-2))
+	} else if !f.directed {
+		scale = 0.5 // Every path was counted from both ends
+	}
+
+	centrality := make(map[T]float64, n)
+	for i, v := range f.values {
+		centrality[v] = betweenness[i] * scale
+	}
+	return centrality, nil
+}
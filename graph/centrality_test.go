@@ -0,0 +1,234 @@
+package graph
+
+import (
+	"math"
+	"testing"
+)
+
+func assertScores[T comparable](t *testing.T, expected, actual map[T]float64) {
+	t.Helper()
+	if len(expected) != len(actual) {
+		t.Errorf("Expected %d scores, got %d: %v", len(expected), len(actual), actual)
+	}
+	for v, want := range expected {
+		if got, ok := actual[v]; !ok || got != want && math.Abs(got-want) > 1e-4 {
+			t.Errorf("Node %v: expected %.5f, got %.5f", v, want, got)
+		}
+	}
+}
+
+func TestPageRank(t *testing.T) {
+	// Reference values computed with networkx.pagerank
+	g := New[string](true)
+	for _, e := range [][2]string{{"A", "B"}, {"A", "C"}, {"B", "C"}, {"C", "A"}, {"D", "C"}} {
+		g.AddEdge(e[0], e[1], 1)
+	}
+
+	ranks, err := g.PageRank()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertScores(
+		t, map[string]float64{
+			"A": 0.37252,
+			"B": 0.19582,
+			"C": 0.39416,
+			"D": 0.0375,
+		}, ranks,
+	)
+
+	sum := 0.0
+	for _, r := range ranks {
+		sum += r
+	}
+	if math.Abs(sum-1) > 1e-6 {
+		t.Errorf("Ranks should sum to 1, got %v", sum)
+	}
+}
+
+func TestPageRank_Options(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddNode(4) // Dangling
+
+	ranks, err := g.PageRank(
+		WithDamping[int](0.5),
+		WithPersonalization(map[int]float64{1: 1}),
+		WithTolerance[int](1e-10),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ranks[4] != 0 {
+		t.Errorf("Node outside the personalization set and without in-edges should have rank 0, got %v", ranks[4])
+	}
+	if !(ranks[1] > ranks[2] && ranks[2] > ranks[3]) {
+		t.Errorf("Expected ranks to decrease along the path, got %v", ranks)
+	}
+
+	if _, err := g.PageRank(WithMaxIterations[int](1)); err != ErrNoConvergence {
+		t.Errorf("Expected ErrNoConvergence, got %v", err)
+	}
+	if _, err := g.PageRank(WithPersonalization(map[int]float64{5: 1})); err != ErrNodeNotFound {
+		t.Errorf("Expected ErrNodeNotFound, got %v", err)
+	}
+
+	empty, err := New[int](true).PageRank()
+	if err != nil || len(empty) != 0 {
+		t.Errorf("Expected empty ranks for empty graph, got %v, %v", empty, err)
+	}
+}
+
+func TestPageRank_Weighted(t *testing.T) {
+	g := New[string](true)
+	g.AddEdge("hub", "a", 3)
+	g.AddEdge("hub", "b", 1)
+	g.AddEdge("a", "hub", 1)
+	g.AddEdge("b", "hub", 1)
+
+	ranks, err := g.PageRank()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ranks["a"] <= ranks["b"] {
+		t.Errorf("Expected a to outrank b through the heavier edge, got %v", ranks)
+	}
+
+	unweighted, err := g.PageRankFunc(func(Edge[string]) float64 { return 1 })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if math.Abs(unweighted["a"]-unweighted["b"]) > 1e-6 {
+		t.Errorf("Unweighted ranks of a and b should be equal, got %v", unweighted)
+	}
+}
+
+func TestDegreeCentrality(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(1, 3, 1)
+	g.AddEdge(2, 3, 1)
+
+	assertScores(t, map[int]float64{1: 1, 2: 1, 3: 1}, g.DegreeCentrality())
+	assertScores(t, map[int]float64{1: 0, 2: 0.5, 3: 1}, g.InDegreeCentrality())
+	assertScores(t, map[int]float64{1: 1, 2: 0.5, 3: 0}, g.OutDegreeCentrality())
+
+	u := New[int](false)
+	u.AddEdge(1, 2, 1)
+	u.AddEdge(1, 3, 1)
+	u.AddEdge(3, 3, 1)
+	assertScores(t, map[int]float64{1: 1, 2: 0.5, 3: 1.5}, u.DegreeCentrality())
+	assertScores(t, u.DegreeCentrality(), u.InDegreeCentrality())
+
+	single := New[int](false)
+	single.AddNode(1)
+	assertScores(t, map[int]float64{1: 1}, single.DegreeCentrality())
+}
+
+func TestClosenessCentrality(t *testing.T) {
+	// Path 1 - 2 - 3 - 4 with a heavy last edge, plus isolated node 5
+	g := New[int](false)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 4, 2)
+	g.AddNode(5)
+
+	closeness, err := g.ClosenessCentrality()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Node 2 reaches 3 nodes at total distance 1+1+3 = 5, scaled by 3/4 reachable
+	assertScores(
+		t, map[int]float64{
+			1: (3.0 / 7) * (3.0 / 4),
+			2: (3.0 / 5) * (3.0 / 4),
+			3: (3.0 / 5) * (3.0 / 4),
+			4: (3.0 / 9) * (3.0 / 4),
+			5: 0,
+		}, closeness,
+	)
+
+	g.AddEdge(4, 5, -1)
+	if _, err := g.ClosenessCentrality(); err != ErrNegativeWeight {
+		t.Errorf("Expected ErrNegativeWeight, got %v", err)
+	}
+}
+
+func TestClosenessCentrality_ZeroDistance(t *testing.T) {
+	// Nodes reaching others only over zero-weight edges are the closest, not the farthest
+	g := New[string](true)
+	g.AddEdge("a", "b", 0)
+	g.AddEdge("b", "c", 0)
+	g.AddEdge("c", "d", 1)
+	g.AddNode("e")
+
+	closeness, err := g.ClosenessCentrality()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertScores(
+		t, map[string]float64{
+			"a": (3.0 / 1) * (3.0 / 4),
+			"b": (2.0 / 1) * (2.0 / 4),
+			"c": (1.0 / 1) * (1.0 / 4),
+			"d": 0,
+			"e": 0,
+		}, closeness,
+	)
+
+	g.RemoveEdge("c", "d")
+	closeness, _ = g.ClosenessCentrality()
+	inf := math.Inf(1)
+	assertScores(t, map[string]float64{"a": inf, "b": inf, "c": 0, "d": 0, "e": 0}, closeness)
+}
+
+func TestBetweennessCentrality(t *testing.T) {
+	// Path 1 - 2 - 3 - 4
+	g := New[int](false)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 4, 1)
+
+	raw, err := g.BetweennessCentrality(false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertScores(t, map[int]float64{1: 0, 2: 2, 3: 2, 4: 0}, raw)
+
+	normalized, err := g.BetweennessCentrality(true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertScores(t, map[int]float64{1: 0, 2: 2.0 / 3, 3: 2.0 / 3, 4: 0}, normalized)
+
+	// Two shortest paths from a to d share the load
+	square := New[string](false)
+	square.AddEdge("a", "b", 1)
+	square.AddEdge("b", "d", 1)
+	square.AddEdge("a", "c", 1)
+	square.AddEdge("c", "d", 1)
+	raw2, err := square.BetweennessCentrality(false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertScores(t, map[string]float64{"a": 0.5, "b": 0.5, "c": 0.5, "d": 0.5}, raw2)
+
+	// The heavy a - b edge is bypassed through c and d
+	square.RemoveEdge("a", "b")
+	square.AddEdge("a", "b", 5)
+	weighted, err := square.BetweennessCentrality(false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertScores(t, map[string]float64{"a": 0, "b": 0, "c": 2, "d": 2}, weighted)
+
+	directed := New[int](true)
+	directed.AddEdge(1, 2, 1)
+	directed.AddEdge(2, 3, 1)
+	raw3, err := directed.BetweennessCentrality(false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertScores(t, map[int]float64{1: 0, 2: 1, 3: 0}, raw3)
+}
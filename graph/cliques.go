@@ -0,0 +1,159 @@
+package graph
+
+import "sort"
+
+// MaximalCliques returns every maximal clique of the graph: the sets of pairwise adjacent nodes
+// that cannot be extended by another node. Isolated nodes form cliques of size one.
+// It uses the Bron-Kerbosch algorithm with pivoting. Edge direction and self-loops are ignored.
+// A graph can have exponentially many maximal cliques; use ForEachMaximalClique to stop early.
+func (g *Graph[T]) MaximalCliques() [][]T {
+	return g.frozen().MaximalCliques()
+}
+
+// ForEachMaximalClique calls visit with every maximal clique until it returns false.
+// The slice passed to visit is reused between calls.
+func (g *Graph[T]) ForEachMaximalClique(visit func(clique []T) bool) {
+	g.frozen().ForEachMaximalClique(visit)
+}
+
+// MaximalCliques returns every maximal clique of the graph.
+// See Graph.MaximalCliques for details.
+func (f *Frozen[T]) MaximalCliques() [][]T {
+	var cliques [][]T
+	f.ForEachMaximalClique(
+		func(clique []T) bool {
+			cliques = append(cliques, append([]T(nil), clique...))
+			return true
+		},
+	)
+	return cliques
+}
+
+// ForEachMaximalClique calls visit with every maximal clique until it returns false.
+// The slice passed to visit is reused between calls.
+func (f *Frozen[T]) ForEachMaximalClique(visit func(clique []T) bool) {
+	bk := &bronKerbosch[T]{
+		values:    f.values,
+		neighbors: f.simpleNeighbors(),
+		visit:     visit,
+	}
+	candidates := make([]int, len(f.values))
+	for i := range candidates {
+		candidates[i] = i
+	}
+	bk.expand(candidates, nil)
+}
+
+type bronKerbosch[T comparable] struct {
+	values    []T
+	neighbors [][]int // Sorted
+	clique    []int
+	buf       []T
+	visit     func([]T) bool
+}
+
+// expand reports every maximal clique extending the current clique with nodes of p
+// and none of x. Both p and x are sorted. It returns false once visit asked to stop.
+func (bk *bronKerbosch[T]) expand(p, x []int) bool {
+	if len(p) == 0 {
+		if len(x) > 0 {
+			return true // Not maximal
+		}
+		bk.buf = bk.buf[:0]
+		for _, u := range bk.clique {
+			bk.buf = append(bk.buf, bk.values[u])
+		}
+		return bk.visit(bk.buf)
+	}
+
+	// Choose the pivot with the most neighbors among the candidates
+	pivot, best := -1, -1
+	for _, set := range [][]int{p, x} {
+		for _, u := range set {
+			if count := countCommon(p, bk.neighbors[u]); count > best {
+				pivot, best = u, count
+			}
+		}
+	}
+
+	// Only candidates that are not neighbors of the pivot need to be branched on
+	var branches []int
+	for _, v := range p {
+		if !containsSorted(bk.neighbors[pivot], v) {
+			branches = append(branches, v)
+		}
+	}
+
+	p = append([]int(nil), p...)
+	x = append([]int(nil), x...)
+	for _, v := range branches {
+		bk.clique = append(bk.clique, v)
+		ok := bk.expand(intersectSorted(p, bk.neighbors[v]), intersectSorted(x, bk.neighbors[v]))
+		bk.clique = bk.clique[:len(bk.clique)-1]
+		if !ok {
+			return false
+		}
+		p = removeSorted(p, v)
+		x = insertSorted(x, v)
+	}
+	return true
+}
+
+func countCommon(a, b []int) int {
+	count := 0
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			count++
+			i++
+			j++
+		}
+	}
+	return count
+}
+
+func intersectSorted(a, b []int) []int {
+	var result []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+func containsSorted(a []int, v int) bool {
+	i := sort.SearchInts(a, v)
+	return i < len(a) && a[i] == v
+}
+
+func removeSorted(a []int, v int) []int {
+	for i, u := range a {
+		if u == v {
+			return append(a[:i], a[i+1:]...)
+		}
+	}
+	return a
+}
+
+func insertSorted(a []int, v int) []int {
+	i := len(a)
+	for i > 0 && a[i-1] > v {
+		i--
+	}
+	a = append(a, 0)
+	copy(a[i+1:], a[i:])
+	a[i] = v
+	return a
+}
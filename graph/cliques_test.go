@@ -0,0 +1,54 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/idsulik/go-collections/v3/internal/slices"
+)
+
+func TestMaximalCliques(t *testing.T) {
+	// Bowtie plus a K4 on 10-13 with a pendant node 14
+	g := bowtieGraph(false)
+	for i := 10; i < 14; i++ {
+		for j := i + 1; j < 14; j++ {
+			g.AddEdge(i, j, 1)
+		}
+	}
+	g.AddEdge(13, 14, 1)
+	g.AddEdge(13, 13, 1)
+
+	cliques := sortedComponents(g.MaximalCliques())
+	expected := [][]int{{1, 2, 3}, {3, 4, 5}, {5, 6}, {7, 8}, {9}, {10, 11, 12, 13}, {13, 14}}
+	if len(cliques) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, cliques)
+	}
+	for i := range expected {
+		if !slices.Equal(cliques[i], expected[i]) {
+			t.Errorf("Expected %v, got %v", expected, cliques)
+			break
+		}
+	}
+
+	// Direction is ignored
+	directed := New[int](true)
+	directed.AddEdge(1, 2, 1)
+	directed.AddEdge(2, 3, 1)
+	directed.AddEdge(3, 1, 1)
+	if cliques := directed.MaximalCliques(); len(cliques) != 1 || len(cliques[0]) != 3 {
+		t.Errorf("Expected a single triangle, got %v", cliques)
+	}
+}
+
+func TestForEachMaximalClique_EarlyStop(t *testing.T) {
+	g := bowtieGraph(false)
+	count := 0
+	g.ForEachMaximalClique(
+		func(clique []int) bool {
+			count++
+			return false
+		},
+	)
+	if count != 1 {
+		t.Errorf("Expected enumeration to stop after one clique, got %d", count)
+	}
+}
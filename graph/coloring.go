@@ -0,0 +1,214 @@
+package graph
+
+import (
+	"sort"
+
+	"github.com/idsulik/go-collections/v3/priorityqueue"
+)
+
+// simpleNeighbors returns, for every node, the sorted indices of its distinct neighbors
+// ignoring edge direction and self-loops.
+func (f *Frozen[T]) simpleNeighbors() [][]int {
+	neighbors := make([][]int, len(f.values))
+	for u := range f.values {
+		row := make([]int, 0, f.undirectedDegree(u))
+		for k := 0; k < f.undirectedDegree(u); k++ {
+			if v, _ := f.undirectedArc(u, k); v != u {
+				row = append(row, v)
+			}
+		}
+		sort.Ints(row)
+
+		unique := row[:0]
+		for i, v := range row {
+			if i == 0 || v != row[i-1] {
+				unique = append(unique, v)
+			}
+		}
+		neighbors[u] = unique
+	}
+	return neighbors
+}
+
+// GreedyColoring assigns every node a color, numbered from 0, so that adjacent nodes get
+// different colors. Nodes are colored in order of decreasing degree, each with the smallest
+// color not used by its neighbors (Welsh-Powell). Edge direction and self-loops are ignored.
+func (g *Graph[T]) GreedyColoring() map[T]int {
+	return g.frozen().GreedyColoring()
+}
+
+// DSaturColoring assigns every node a color like GreedyColoring, but always colors next the node
+// whose neighbors already use the most distinct colors, breaking ties by degree. It usually needs
+// fewer colors than GreedyColoring and is exact for bipartite graphs, cycles and wheels.
+func (g *Graph[T]) DSaturColoring() map[T]int {
+	return g.frozen().DSaturColoring()
+}
+
+// GreedyColoring assigns every node a color so that adjacent nodes get different colors.
+// See Graph.GreedyColoring for details.
+func (f *Frozen[T]) GreedyColoring() map[T]int {
+	neighbors := f.simpleNeighbors()
+	order := make([]int, len(f.values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(
+		order, func(i, j int) bool {
+			return len(neighbors[order[i]]) > len(neighbors[order[j]])
+		},
+	)
+
+	colors := make([]int, len(f.values))
+	for i := range colors {
+		colors[i] = -1
+	}
+	// usedBy[c] == u+1 marks color c as taken by a neighbor of u
+	usedBy := make([]int, len(f.values)+1)
+	for _, u := range order {
+		for _, v := range neighbors[u] {
+			if c := colors[v]; c >= 0 {
+				usedBy[c] = u + 1
+			}
+		}
+		c := 0
+		for usedBy[c] == u+1 {
+			c++
+		}
+		colors[u] = c
+	}
+	return f.colorMap(colors)
+}
+
+// dsaturEntry is a candidate of DSaturColoring with the saturation it had when pushed.
+type dsaturEntry struct {
+	node       int
+	saturation int
+	degree     int
+}
+
+// DSaturColoring assigns every node a color, choosing the most saturated node first.
+// See Graph.DSaturColoring for details.
+func (f *Frozen[T]) DSaturColoring() map[T]int {
+	neighbors := f.simpleNeighbors()
+	n := len(f.values)
+	colors := make([]int, n)
+	for i := range colors {
+		colors[i] = -1
+	}
+	// neighborColors[u] holds the distinct colors of the colored neighbors of u
+	neighborColors := make([]map[int]bool, n)
+
+	pq := priorityqueue.New[dsaturEntry](
+		func(a, b dsaturEntry) bool {
+			if a.saturation != b.saturation {
+				return a.saturation > b.saturation
+			}
+			if a.degree != b.degree {
+				return a.degree > b.degree
+			}
+			return a.node < b.node
+		},
+	)
+	for u := 0; u < n; u++ {
+		pq.Push(dsaturEntry{node: u, degree: len(neighbors[u])})
+	}
+
+	usedBy := make([]int, n+1)
+	for !pq.IsEmpty() {
+		entry, _ := pq.Pop()
+		u := entry.node
+		if colors[u] >= 0 || entry.saturation != len(neighborColors[u]) {
+			continue // Already colored or superseded by an entry with higher saturation
+		}
+
+		for _, v := range neighbors[u] {
+			if c := colors[v]; c >= 0 {
+				usedBy[c] = u + 1
+			}
+		}
+		c := 0
+		for usedBy[c] == u+1 {
+			c++
+		}
+		colors[u] = c
+
+		for _, v := range neighbors[u] {
+			if colors[v] >= 0 || neighborColors[v][c] {
+				continue
+			}
+			if neighborColors[v] == nil {
+				neighborColors[v] = make(map[int]bool)
+			}
+			neighborColors[v][c] = true
+			pq.Push(dsaturEntry{node: v, saturation: len(neighborColors[v]), degree: len(neighbors[v])})
+		}
+	}
+	return f.colorMap(colors)
+}
+
+func (f *Frozen[T]) colorMap(colors []int) map[T]int {
+	result := make(map[T]int, len(colors))
+	for i, c := range colors {
+		result[f.values[i]] = c
+	}
+	return result
+}
+
+// Bipartition splits the nodes into two sides such that every edge connects nodes on different sides.
+// The sides are a two-coloring that witnesses that the graph is bipartite; ok is false if no such
+// split exists, that is if the graph has an odd cycle or a self-loop. Edge direction is ignored.
+func (g *Graph[T]) Bipartition() (left, right []T, ok bool) {
+	return g.frozen().Bipartition()
+}
+
+// IsBipartite reports whether the nodes can be split into two sides with no edge inside a side.
+func (g *Graph[T]) IsBipartite() bool {
+	_, _, ok := g.Bipartition()
+	return ok
+}
+
+// Bipartition splits the nodes into two sides such that every edge connects nodes on different sides.
+// See Graph.Bipartition for details.
+func (f *Frozen[T]) Bipartition() (left, right []T, ok bool) {
+	side := make([]int, len(f.values))
+	for i := range side {
+		side[i] = -1
+	}
+
+	var queue []int
+	for root := range f.values {
+		if side[root] >= 0 {
+			continue
+		}
+		side[root] = 0
+		queue = append(queue[:0], root)
+		for head := 0; head < len(queue); head++ {
+			u := queue[head]
+			for k := 0; k < f.undirectedDegree(u); k++ {
+				v, _ := f.undirectedArc(u, k)
+				switch side[v] {
+				case -1:
+					side[v] = 1 - side[u]
+					queue = append(queue, v)
+				case side[u]:
+					return nil, nil, false
+				}
+			}
+		}
+	}
+
+	for i, s := range side {
+		if s == 0 {
+			left = append(left, f.values[i])
+		} else {
+			right = append(right, f.values[i])
+		}
+	}
+	return left, right, true
+}
+
+// IsBipartite reports whether the nodes can be split into two sides with no edge inside a side.
+func (f *Frozen[T]) IsBipartite() bool {
+	_, _, ok := f.Bipartition()
+	return ok
+}
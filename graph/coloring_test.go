@@ -0,0 +1,141 @@
+package graph
+
+import "testing"
+
+// assertProperColoring checks that no edge connects two nodes of the same color.
+func assertProperColoring[T comparable](t *testing.T, g *Graph[T], colors map[T]int) int {
+	t.Helper()
+	if len(colors) != len(g.Nodes()) {
+		t.Errorf("Expected a color for each of the %d nodes, got %v", len(g.Nodes()), colors)
+	}
+	used := make(map[int]bool)
+	for v, c := range colors {
+		used[c] = true
+		for _, u := range g.Neighbors(v) {
+			if u != v && colors[u] == c {
+				t.Errorf("Adjacent nodes %v and %v share color %d", u, v, c)
+			}
+		}
+	}
+	return len(used)
+}
+
+// crownGraph builds the bipartite graph on u0..u3 and v0..v3 in which ui and vj are adjacent if i != j.
+// Greedy coloring in the order u0, v0, u1, v1, ... needs four colors, while two suffice.
+func crownGraph() *Graph[string] {
+	g := New[string](false)
+	left := []string{"u0", "u1", "u2", "u3"}
+	right := []string{"v0", "v1", "v2", "v3"}
+	for i, u := range left {
+		for j, v := range right {
+			if i != j {
+				g.AddEdge(u, v, 1)
+			}
+		}
+	}
+	return g
+}
+
+func TestColoring(t *testing.T) {
+	tests := []struct {
+		name   string
+		graph  func() *Graph[int]
+		dsatur int // Colors DSatur is expected to use
+	}{
+		{"odd cycle", func() *Graph[int] { return cycleGraph(7, false) }, 3},
+		{"even cycle", func() *Graph[int] { return cycleGraph(8, false) }, 2},
+		{"directed cycle", func() *Graph[int] { return cycleGraph(5, true) }, 3},
+		{"complete", func() *Graph[int] { return completeGraph(5) }, 5},
+		{"bowtie", func() *Graph[int] { return bowtieGraph(false) }, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				g := tt.graph()
+				assertProperColoring(t, g, g.GreedyColoring())
+				if used := assertProperColoring(t, g, g.DSaturColoring()); used != tt.dsatur {
+					t.Errorf("Expected DSatur to use %d colors, got %d", tt.dsatur, used)
+				}
+			},
+		)
+	}
+
+	crown := crownGraph()
+	if used := assertProperColoring(t, crown, crown.DSaturColoring()); used != 2 {
+		t.Errorf("Expected DSatur to two-color the crown graph, used %d colors", used)
+	}
+
+	// Self-loops do not prevent a coloring
+	g := New[int](false)
+	g.AddEdge(1, 1, 1)
+	g.AddEdge(1, 2, 1)
+	assertProperColoring(t, g, g.GreedyColoring())
+}
+
+// cycleGraph builds the cycle 0-1-...-(n-1)-0.
+func cycleGraph(n int, directed bool) *Graph[int] {
+	g := New[int](directed)
+	for i := 0; i < n; i++ {
+		g.AddEdge(i, (i+1)%n, 1)
+	}
+	return g
+}
+
+// completeGraph builds the complete undirected graph on the nodes 0 to n-1.
+func completeGraph(n int) *Graph[int] {
+	g := New[int](false)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			g.AddEdge(i, j, 1)
+		}
+	}
+	return g
+}
+
+func TestBipartition(t *testing.T) {
+	crown := crownGraph()
+	left, right, ok := crown.Bipartition()
+	if !ok {
+		t.Fatal("Expected the crown graph to be bipartite")
+	}
+	side := make(map[string]int)
+	for _, v := range left {
+		side[v] = 1
+	}
+	for _, v := range right {
+		side[v] = 2
+	}
+	if len(side) != 8 {
+		t.Errorf("Expected every node on exactly one side, got %v and %v", left, right)
+	}
+	for _, e := range crown.Edges() {
+		if side[e[0]] == side[e[1]] {
+			t.Errorf("Edge %v lies inside one side", e)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		graph    *Graph[int]
+		expected bool
+	}{
+		{"even cycle", cycleGraph(6, false), true},
+		{"odd cycle", cycleGraph(5, false), false},
+		{"directed odd cycle", cycleGraph(3, true), false},
+		{"triangles", bowtieGraph(false), false},
+		{"tree with isolated component", treeGraph(), true},
+		{"empty", New[int](false), true},
+	}
+	for _, tt := range tests {
+		if got := tt.graph.IsBipartite(); got != tt.expected {
+			t.Errorf("%s: expected IsBipartite %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+
+	loop := New[int](true)
+	loop.AddEdge(1, 1, 1)
+	if loop.IsBipartite() {
+		t.Error("A self-loop should make the graph non-bipartite")
+	}
+}
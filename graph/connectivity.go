@@ -0,0 +1,212 @@
+package graph
+
+// undirectedDegree returns the number of edges touching node u when direction is ignored.
+func (f *Frozen[T]) undirectedDegree(u int) int {
+	degree := f.offsets[u+1] - f.offsets[u]
+	if f.directed {
+		degree += f.inOffsets[u+1] - f.inOffsets[u]
+	}
+	return degree
+}
+
+// undirectedArc returns the other endpoint and ID of the k-th edge touching node u
+// when direction is ignored: outgoing edges first, then incoming edges of directed graphs.
+func (f *Frozen[T]) undirectedArc(u, k int) (int, int) {
+	if out := f.offsets[u+1] - f.offsets[u]; k >= out {
+		p := f.inOffsets[u] + k - out
+		return f.inSources[p], f.ids[f.inArcs[p]]
+	}
+	a := f.offsets[u] + k
+	return f.targets[a], f.ids[a]
+}
+
+// ConnectedComponents returns the node sets of the connected components of the graph.
+// For directed graphs edge direction is ignored, which yields the weakly connected components.
+func (g *Graph[T]) ConnectedComponents() [][]T {
+	return g.frozen().ConnectedComponents()
+}
+
+// ConnectedComponents returns the node sets of the connected components of the graph.
+// For directed graphs edge direction is ignored, which yields the weakly connected components.
+func (f *Frozen[T]) ConnectedComponents() [][]T {
+	seen := make([]bool, len(f.values))
+
+	var components [][]T
+	var stack []int
+	for root := range f.values {
+		if seen[root] {
+			continue
+		}
+		seen[root] = true
+		stack = append(stack[:0], root)
+		var component []T
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			component = append(component, f.values[u])
+			for k := 0; k < f.undirectedDegree(u); k++ {
+				if v, _ := f.undirectedArc(u, k); !seen[v] {
+					seen[v] = true
+					stack = append(stack, v)
+				}
+			}
+		}
+		components = append(components, component)
+	}
+	return components
+}
+
+// Bridges returns the edges whose removal disconnects their endpoints.
+// Parallel edges of a multigraph are never bridges. Edge direction is ignored.
+func (g *Graph[T]) Bridges() [][2]T {
+	return g.frozen().Bridges()
+}
+
+// Bridges returns the edges whose removal disconnects their endpoints.
+// Parallel edges of a multigraph are never bridges. Edge direction is ignored.
+func (f *Frozen[T]) Bridges() [][2]T {
+	return f.biconnectivity().bridges
+}
+
+// ArticulationPoints returns the nodes whose removal increases the number of connected components.
+// Edge direction is ignored.
+func (g *Graph[T]) ArticulationPoints() []T {
+	return g.frozen().ArticulationPoints()
+}
+
+// ArticulationPoints returns the nodes whose removal increases the number of connected components.
+// Edge direction is ignored.
+func (f *Frozen[T]) ArticulationPoints() []T {
+	return f.biconnectivity().articulationPoints
+}
+
+// BiconnectedComponents returns the node sets of the maximal biconnected subgraphs.
+// Every edge belongs to exactly one component, so a bridge forms a component of two nodes
+// and articulation points appear in several components. Isolated nodes are not reported.
+// Edge direction is ignored.
+func (g *Graph[T]) BiconnectedComponents() [][]T {
+	return g.frozen().BiconnectedComponents()
+}
+
+// BiconnectedComponents returns the node sets of the maximal biconnected subgraphs.
+// See Graph.BiconnectedComponents for details.
+func (f *Frozen[T]) BiconnectedComponents() [][]T {
+	return f.biconnectivity().components
+}
+
+type biconnectivity[T comparable] struct {
+	bridges            [][2]T
+	articulationPoints []T
+	components         [][]T
+}
+
+// tarjanFrame is an entry of the explicit stack used by biconnectivity.
+type tarjanFrame struct {
+	node       int
+	parentEdge int
+	next       int
+}
+
+// biconnectivity runs Tarjan's low-link algorithm with an explicit stack,
+// so that deep graphs cannot overflow the goroutine stack.
+func (f *Frozen[T]) biconnectivity() biconnectivity[T] {
+	values := f.values
+	disc := make([]int, len(values))
+	low := make([]int, len(values))
+	for i := range disc {
+		disc[i] = -1
+	}
+	isCut := make([]bool, len(values))
+
+	var result biconnectivity[T]
+	var edgeStack [][2]int
+	time := 0
+
+	// popComponent collects the nodes of the edges stacked above and including (parent, child).
+	popComponent := func(parent, child int) {
+		inComponent := make(map[int]bool)
+		var component []T
+		for {
+			e := edgeStack[len(edgeStack)-1]
+			edgeStack = edgeStack[:len(edgeStack)-1]
+			for _, v := range e {
+				if !inComponent[v] {
+					inComponent[v] = true
+					component = append(component, values[v])
+				}
+			}
+			if e[0] == parent && e[1] == child {
+				break
+			}
+		}
+		result.components = append(result.components, component)
+	}
+
+	for root := range values {
+		if disc[root] != -1 {
+			continue
+		}
+		disc[root], low[root] = time, time
+		time++
+		rootChildren := 0
+		stack := []tarjanFrame{{node: root, parentEdge: -1}}
+
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			u := top.node
+
+			if top.next < f.undirectedDegree(u) {
+				v, id := f.undirectedArc(u, top.next)
+				top.next++
+				if id == top.parentEdge || v == u {
+					continue // Tree edge to the parent or self-loop
+				}
+				if disc[v] == -1 {
+					disc[v], low[v] = time, time
+					time++
+					edgeStack = append(edgeStack, [2]int{u, v})
+					if u == root {
+						rootChildren++
+					}
+					stack = append(stack, tarjanFrame{node: v, parentEdge: id})
+				} else if disc[v] < disc[u] {
+					// Back edge to an ancestor
+					if disc[v] < low[u] {
+						low[u] = disc[v]
+					}
+					edgeStack = append(edgeStack, [2]int{u, v})
+				}
+				continue
+			}
+
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				break
+			}
+			parent := stack[len(stack)-1].node
+			if low[u] < low[parent] {
+				low[parent] = low[u]
+			}
+			if low[u] > disc[parent] {
+				result.bridges = append(result.bridges, [2]T{values[parent], values[u]})
+			}
+			if low[u] >= disc[parent] {
+				if parent != root {
+					isCut[parent] = true
+				}
+				popComponent(parent, u)
+			}
+		}
+
+		if rootChildren > 1 {
+			isCut[root] = true
+		}
+	}
+
+	for i, cut := range isCut {
+		if cut {
+			result.articulationPoints = append(result.articulationPoints, values[i])
+		}
+	}
+	return result
+}
@@ -0,0 +1,137 @@
+package graph
+
+import (
+	"sort"
+	"testing"
+)
+
+// sortedComponents normalizes components for comparison.
+func sortedComponents(components [][]int) [][]int {
+	for _, c := range components {
+		sort.Ints(c)
+	}
+	sort.Slice(
+		components, func(i, j int) bool {
+			a, b := components[i], components[j]
+			for k := 0; k < len(a) && k < len(b); k++ {
+				if a[k] != b[k] {
+					return a[k] < b[k]
+				}
+			}
+			return len(a) < len(b)
+		},
+	)
+	return components
+}
+
+// bowtieGraph builds two triangles 1-2-3 and 3-4-5 joined at 3, a bridge 5-6 and a separate edge 7-8.
+func bowtieGraph(directed bool) *Graph[int] {
+	g := New[int](directed)
+	for _, e := range [][2]int{{1, 2}, {2, 3}, {3, 1}, {3, 4}, {4, 5}, {5, 3}, {5, 6}, {7, 8}} {
+		g.AddEdge(e[0], e[1], 1)
+	}
+	g.AddNode(9)
+	return g
+}
+
+func TestConnectedComponents(t *testing.T) {
+	for _, directed := range []bool{false, true} {
+		components := sortedComponents(bowtieGraph(directed).ConnectedComponents())
+		expected := [][]int{{1, 2, 3, 4, 5, 6}, {7, 8}, {9}}
+		if len(components) != len(expected) {
+			t.Fatalf("directed=%v: expected %v, got %v", directed, expected, components)
+		}
+		for i := range expected {
+			if len(components[i]) != len(expected[i]) {
+				t.Errorf("directed=%v: expected %v, got %v", directed, expected, components)
+				break
+			}
+			for j := range expected[i] {
+				if components[i][j] != expected[i][j] {
+					t.Errorf("directed=%v: expected %v, got %v", directed, expected, components)
+				}
+			}
+		}
+	}
+}
+
+func TestBridges(t *testing.T) {
+	g := bowtieGraph(false)
+	bridges := make(map[[2]int]bool)
+	for _, b := range g.Bridges() {
+		if b[0] > b[1] {
+			b[0], b[1] = b[1], b[0]
+		}
+		bridges[b] = true
+	}
+
+	if len(bridges) != 2 || !bridges[[2]int{5, 6}] || !bridges[[2]int{7, 8}] {
+		t.Errorf("Expected bridges 5-6 and 7-8, got %v", g.Bridges())
+	}
+}
+
+func TestBridges_ParallelEdges(t *testing.T) {
+	g := NewMultigraph[int](false)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+
+	bridges := g.Bridges()
+	if len(bridges) != 1 {
+		t.Fatalf("Expected a single bridge, got %v", bridges)
+	}
+	if b := bridges[0]; !(b == [2]int{2, 3} || b == [2]int{3, 2}) {
+		t.Errorf("Expected bridge 2-3, got %v", b)
+	}
+}
+
+func TestArticulationPoints(t *testing.T) {
+	g := bowtieGraph(false)
+	points := g.ArticulationPoints()
+	sort.Ints(points)
+
+	if len(points) != 2 || points[0] != 3 || points[1] != 5 {
+		t.Errorf("Expected articulation points [3 5], got %v", points)
+	}
+
+	star := New[int](false)
+	star.AddEdge(0, 1, 1)
+	star.AddEdge(0, 2, 1)
+	if points := star.ArticulationPoints(); len(points) != 1 || points[0] != 0 {
+		t.Errorf("Expected root of star to be an articulation point, got %v", points)
+	}
+}
+
+func TestBiconnectedComponents(t *testing.T) {
+	g := bowtieGraph(false)
+	components := sortedComponents(g.BiconnectedComponents())
+
+	expected := [][]int{{1, 2, 3}, {3, 4, 5}, {5, 6}, {7, 8}}
+	if len(components) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, components)
+	}
+	for i := range expected {
+		for j := range expected[i] {
+			if j >= len(components[i]) || components[i][j] != expected[i][j] {
+				t.Errorf("Expected %v, got %v", expected, components)
+				return
+			}
+		}
+	}
+}
+
+func TestConnectivity_DeepGraph(t *testing.T) {
+	// A long path must not overflow the stack, and every edge of it is a bridge
+	g := New[int](false)
+	const n = 100000
+	for i := 0; i < n; i++ {
+		g.AddEdge(i, i+1, 1)
+	}
+
+	if bridges := g.Bridges(); len(bridges) != n {
+		t.Errorf("Expected %d bridges, got %d", n, len(bridges))
+	}
+	if points := g.ArticulationPoints(); len(points) != n-1 {
+		t.Errorf("Expected %d articulation points, got %d", n-1, len(points))
+	}
+}
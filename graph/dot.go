@@ -0,0 +1,365 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// WriteDOT writes the graph in the Graphviz DOT language.
+// Directed graphs are written as a digraph and every edge carries its weight as an attribute.
+func (g *Graph[T]) WriteDOT(w io.Writer, opts ...EncodingOption[T]) error {
+	enc := newEncoding(opts)
+	nodes, ids := g.sortedNodes(enc)
+
+	kind, op := "graph", "--"
+	if g.directed {
+		kind, op = "digraph", "->"
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s {\n", kind)
+	for _, v := range nodes {
+		if enc.label != nil {
+			fmt.Fprintf(bw, "\t%s [label=%s];\n", dotQuote(ids[v]), dotQuote(enc.label(v)))
+		} else {
+			fmt.Fprintf(bw, "\t%s;\n", dotQuote(ids[v]))
+		}
+	}
+	for _, e := range g.sortedEdges(nodes, ids) {
+		fmt.Fprintf(
+			bw, "\t%s %s %s [weight=%s];\n",
+			dotQuote(ids[e.from.value]), op, dotQuote(ids[e.to.value]),
+			strconv.FormatFloat(e.weight, 'g', -1, 64),
+		)
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// ReadDOT parses a graph written in the common subset of the DOT language:
+// node and edge statements (including chains such as a -> b -> c), attribute lists,
+// and comments. Edge weights are read from the "weight" attribute and default to 1.
+// Graph, node and edge default attribute statements are accepted and ignored.
+// A repeated edge makes the result a multigraph, so graphs written from a multigraph keep their
+// parallel edges. In a strict graph a repeated edge is instead merged into the first one,
+// taking the last weight given.
+// Subgraphs and ports are not supported.
+func ReadDOT[T comparable](r io.Reader, opts ...EncodingOption[T]) (*Graph[T], error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := lexDOT(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &dotParser[T]{tokens: tokens, enc: newEncoding(opts)}
+	return p.parse()
+}
+
+type dotTokenKind int
+
+const (
+	dotEOF dotTokenKind = iota
+	dotID
+	dotPunct
+)
+
+type dotToken struct {
+	kind   dotTokenKind
+	text   string
+	quoted bool
+	line   int
+}
+
+func lexDOT(src string) ([]dotToken, error) {
+	var tokens []dotToken
+	line := 1
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '#' || strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("graph: dot: line %d: unterminated comment", line)
+			}
+			line += strings.Count(src[i:i+2+end], "\n")
+			i += end + 4
+		case strings.HasPrefix(src[i:], "--") || strings.HasPrefix(src[i:], "->"):
+			tokens = append(tokens, dotToken{kind: dotPunct, text: src[i : i+2], line: line})
+			i += 2
+		case strings.ContainsRune("{}[];,=:", rune(c)):
+			tokens = append(tokens, dotToken{kind: dotPunct, text: string(c), line: line})
+			i++
+		case c == '"':
+			var sb strings.Builder
+			start := line
+			i++
+			for {
+				if i >= len(src) {
+					return nil, fmt.Errorf("graph: dot: line %d: unterminated string", start)
+				}
+				if src[i] == '"' {
+					i++
+					break
+				}
+				if src[i] == '\\' && i+1 < len(src) && (src[i+1] == '"' || src[i+1] == '\\') {
+					i++
+				}
+				if src[i] == '\n' {
+					line++
+				}
+				sb.WriteByte(src[i])
+				i++
+			}
+			tokens = append(tokens, dotToken{kind: dotID, text: sb.String(), quoted: true, line: start})
+		case c == '_' || c >= 0x80 || unicode.IsLetter(rune(c)):
+			start := i
+			for i < len(src) && (src[i] == '_' || src[i] >= 0x80 || unicode.IsLetter(rune(src[i])) || isDigit(src[i])) {
+				i++
+			}
+			tokens = append(tokens, dotToken{kind: dotID, text: src[start:i], line: line})
+		case isDigit(c) || c == '.' || c == '-':
+			start := i
+			i++
+			for i < len(src) && (isDigit(src[i]) || src[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, dotToken{kind: dotID, text: src[start:i], line: line})
+		default:
+			return nil, fmt.Errorf("graph: dot: line %d: unexpected character %q", line, c)
+		}
+	}
+
+	return append(tokens, dotToken{kind: dotEOF, line: line}), nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+type dotParser[T comparable] struct {
+	tokens []dotToken
+	pos    int
+	enc    *encoding[T]
+	graph  *Graph[T]
+	strict bool
+}
+
+func (p *dotParser[T]) peek() dotToken {
+	return p.tokens[p.pos]
+}
+
+func (p *dotParser[T]) next() dotToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != dotEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// keyword reports whether tok is the unquoted, case-insensitive keyword kw.
+func keyword(tok dotToken, kw string) bool {
+	return tok.kind == dotID && !tok.quoted && strings.EqualFold(tok.text, kw)
+}
+
+// errorf formats an error at the line of tok. Like fmt.Errorf, it wraps an error given for %w.
+func (p *dotParser[T]) errorf(tok dotToken, format string, args ...any) error {
+	return fmt.Errorf("graph: dot: line %d: "+format, append([]any{tok.line}, args...)...)
+}
+
+func (p *dotParser[T]) expect(text string) error {
+	if tok := p.next(); tok.kind != dotPunct || tok.text != text {
+		return p.errorf(tok, "expected %q, got %q", text, tok.text)
+	}
+	return nil
+}
+
+func (p *dotParser[T]) parse() (*Graph[T], error) {
+	if keyword(p.peek(), "strict") {
+		p.next()
+		p.strict = true
+	}
+
+	tok := p.next()
+	switch {
+	case keyword(tok, "graph"):
+		p.graph = New[T](false)
+	case keyword(tok, "digraph"):
+		p.graph = New[T](true)
+	default:
+		return nil, p.errorf(tok, "expected graph or digraph, got %q", tok.text)
+	}
+
+	if p.peek().kind == dotID {
+		p.next()
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		switch {
+		case tok.kind == dotEOF:
+			return nil, p.errorf(tok, "unexpected end of input")
+		case tok.kind == dotPunct && tok.text == "}":
+			p.next()
+			if tok := p.next(); tok.kind != dotEOF {
+				return nil, p.errorf(tok, "unexpected %q after graph", tok.text)
+			}
+			return p.graph, nil
+		case tok.kind == dotPunct && tok.text == ";":
+			p.next()
+		default:
+			if err := p.statement(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (p *dotParser[T]) statement() error {
+	tok := p.next()
+	switch {
+	case keyword(tok, "subgraph") || (tok.kind == dotPunct && tok.text == "{"):
+		return p.errorf(tok, "subgraphs are not supported")
+	case keyword(tok, "graph") || keyword(tok, "node") || keyword(tok, "edge"):
+		_, err := p.attributes()
+		return err
+	case tok.kind != dotID:
+		return p.errorf(tok, "unexpected %q", tok.text)
+	}
+
+	// Graph attribute assignment: ID = ID
+	if next := p.peek(); next.kind == dotPunct && next.text == "=" {
+		p.next()
+		if value := p.next(); value.kind != dotID {
+			return p.errorf(value, "expected attribute value, got %q", value.text)
+		}
+		return nil
+	}
+
+	chain := []dotToken{tok}
+	for {
+		op := p.peek()
+		if op.kind != dotPunct || (op.text != "--" && op.text != "->") {
+			break
+		}
+		p.next()
+		if p.graph.directed != (op.text == "->") {
+			return p.errorf(op, "edge operator %q does not match graph type", op.text)
+		}
+		target := p.next()
+		if target.kind != dotID {
+			return p.errorf(target, "expected node identifier, got %q", target.text)
+		}
+		chain = append(chain, target)
+	}
+
+	if next := p.peek(); next.kind == dotPunct && next.text == ":" {
+		return p.errorf(next, "ports are not supported")
+	}
+
+	attrs, err := p.attributes()
+	if err != nil {
+		return err
+	}
+
+	values := make([]T, len(chain))
+	for i, t := range chain {
+		if values[i], err = p.enc.node(t.text); err != nil {
+			return p.errorf(t, "%w", err)
+		}
+	}
+
+	if len(values) == 1 {
+		p.graph.AddNode(values[0])
+		return nil
+	}
+
+	weight := 1.0
+	s, weighted := attrs["weight"]
+	if weighted {
+		if weight, err = strconv.ParseFloat(s, 64); err != nil {
+			return p.errorf(tok, "invalid weight %q: %w", s, err)
+		}
+	}
+	for i := 1; i < len(values); i++ {
+		p.addEdge(values[i-1], values[i], weight, weighted)
+	}
+	return nil
+}
+
+// addEdge adds an edge from an edge statement. A repeated edge turns the graph into a multigraph,
+// unless the graph is strict, where it takes the weight of the new statement if it sets one.
+func (p *dotParser[T]) addEdge(from, to T, weight float64, weighted bool) {
+	if p.graph.AddEdge(from, to, weight) {
+		return
+	}
+	if !p.strict {
+		p.graph.multi = true
+		p.graph.AddEdge(from, to, weight)
+		return
+	}
+	if !weighted {
+		return
+	}
+	p.graph.changed()
+	p.graph.nodes[from].edges[to].weight = weight
+	if !p.graph.directed {
+		p.graph.nodes[to].edges[from].weight = weight
+	}
+}
+
+// attributes parses zero or more bracketed attribute lists.
+func (p *dotParser[T]) attributes() (map[string]string, error) {
+	attrs := make(map[string]string)
+	for {
+		if tok := p.peek(); tok.kind != dotPunct || tok.text != "[" {
+			return attrs, nil
+		}
+		p.next()
+		for {
+			tok := p.next()
+			if tok.kind == dotPunct && tok.text == "]" {
+				break
+			}
+			if tok.kind == dotPunct && (tok.text == "," || tok.text == ";") {
+				continue
+			}
+			if tok.kind != dotID {
+				return nil, p.errorf(tok, "expected attribute name, got %q", tok.text)
+			}
+			value := "true"
+			if next := p.peek(); next.kind == dotPunct && next.text == "=" {
+				p.next()
+				v := p.next()
+				if v.kind != dotID {
+					return nil, p.errorf(v, "expected attribute value, got %q", v.text)
+				}
+				value = v.text
+			}
+			attrs[tok.text] = value
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWriteDOT(t *testing.T) {
+	tests := []struct {
+		name     string
+		directed bool
+		expected string
+	}{
+		{
+			name:     "undirected",
+			directed: false,
+			expected: "graph {\n\t\"a\";\n\t\"b\";\n\t\"c\";\n\t\"a\" -- \"b\" [weight=1.5];\n\t\"b\" -- \"c\" [weight=2];\n}\n",
+		},
+		{
+			name:     "directed",
+			directed: true,
+			expected: "digraph {\n\t\"a\";\n\t\"b\";\n\t\"c\";\n\t\"a\" -> \"b\" [weight=1.5];\n\t\"c\" -> \"b\" [weight=2];\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				g := New[string](tt.directed)
+				g.AddEdge("a", "b", 1.5)
+				g.AddEdge("c", "b", 2)
+
+				var buf bytes.Buffer
+				if err := g.WriteDOT(&buf); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if buf.String() != tt.expected {
+					t.Errorf("Unexpected output:\n%s\nwant:\n%s", buf.String(), tt.expected)
+				}
+			},
+		)
+	}
+}
+
+func TestWriteDOT_Formatter(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(1, 2, 1)
+
+	var buf bytes.Buffer
+	err := g.WriteDOT(
+		&buf,
+		WithNodeID(func(v int) string { return "n" + strconv.Itoa(v) }),
+		WithNodeLabel(func(v int) string { return "say \"" + strconv.Itoa(v) + "\"" }),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `"n1" [label="say \"1\""];`) {
+		t.Errorf("Expected formatted node with escaped label, got:\n%s", out)
+	}
+	if !strings.Contains(out, `"n1" -> "n2" [weight=1];`) {
+		t.Errorf("Expected formatted edge, got:\n%s", out)
+	}
+}
+
+func TestReadDOT(t *testing.T) {
+	src := `
+		/* flights */
+		strict digraph routes {
+			rankdir = LR;
+			node [shape=box]
+			# isolated airport
+			"San Jose"
+			AMS -> LHR -> JFK [weight=2.5, color="red"];
+			JFK -> "San Jose" // default weight
+			edge [style=dashed]
+		}
+	`
+
+	g, err := ReadDOT[string](strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(g.Nodes()) != 4 {
+		t.Errorf("Expected 4 nodes, got %d", len(g.Nodes()))
+	}
+	if w, ok := g.GetEdgeWeight("AMS", "LHR"); !ok || w != 2.5 {
+		t.Errorf("Expected AMS->LHR with weight 2.5, got %v, %v", w, ok)
+	}
+	if w, ok := g.GetEdgeWeight("LHR", "JFK"); !ok || w != 2.5 {
+		t.Errorf("Expected LHR->JFK with weight 2.5, got %v, %v", w, ok)
+	}
+	if w, ok := g.GetEdgeWeight("JFK", "San Jose"); !ok || w != 1 {
+		t.Errorf("Expected JFK->San Jose with weight 1, got %v, %v", w, ok)
+	}
+	if g.HasEdge("LHR", "AMS") {
+		t.Error("Digraph edges should be directed")
+	}
+}
+
+func TestReadDOT_Strict(t *testing.T) {
+	for _, directed := range []bool{false, true} {
+		kind, op := "graph", "--"
+		if directed {
+			kind, op = "digraph", "->"
+		}
+		src := "strict " + kind + " { a " + op + " b [weight=2]; a " + op + " b [weight=5]; a " + op + " b }"
+
+		g, err := ReadDOT[string](strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(g.Edges()) != 1 {
+			t.Errorf("directed=%v: expected repeated edges to be merged, got %v", directed, g.Edges())
+		}
+		if w, _ := g.GetEdgeWeight("a", "b"); w != 5 {
+			t.Errorf("directed=%v: expected the last weight 5, got %v", directed, w)
+		}
+		if e, _ := g.GetEdge("b", "a"); !directed && e.Weight != 5 {
+			t.Errorf("Expected the reverse direction to have weight 5, got %v", e.Weight)
+		}
+	}
+}
+
+func TestReadDOT_RoundTrip(t *testing.T) {
+	g := New[int](false)
+	g.AddEdge(1, 2, 0.5)
+	g.AddEdge(2, 3, -4)
+	g.AddNode(10)
+
+	var buf bytes.Buffer
+	if err := g.WriteDOT(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	decoded, err := ReadDOT[int](&buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertSameGraph(t, g, decoded)
+}
+
+func TestReadDOT_Multigraph(t *testing.T) {
+	for _, directed := range []bool{false, true} {
+		g := parallelGraph(directed)
+
+		var buf bytes.Buffer
+		if err := g.WriteDOT(&buf); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		decoded, err := ReadDOT[int](&buf)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		assertSameMultigraph(t, g, decoded)
+	}
+
+	if g, _ := ReadDOT[int](strings.NewReader("graph { 1 -- 2 }")); g.IsMultigraph() {
+		t.Error("Expected a simple graph without repeated edges")
+	}
+}
+
+func TestReadDOT_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"missing header", "{ a }"},
+		{"wrong edge operator", "graph { a -> b }"},
+		{"subgraph", "graph { subgraph x { a } }"},
+		{"port", "graph { a:n -- b }"},
+		{"unterminated", "graph { a -- b"},
+		{"unterminated string", `graph { "a }`},
+		{"bad weight", "graph { a -- b [weight=x] }"},
+		{"trailing input", "graph { } graph { }"},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				if _, err := ReadDOT[string](strings.NewReader(tt.src)); err == nil {
+					t.Error("Expected error")
+				}
+			},
+		)
+	}
+
+	if _, err := ReadDOT[int](strings.NewReader("graph { a }")); err == nil {
+		t.Error("Expected error for node that cannot be parsed as int")
+	}
+
+	errUnknown := errors.New("unknown node")
+	parse := func(string) (int, error) { return 0, errUnknown }
+	_, err := ReadDOT(strings.NewReader("graph {\n a\n}"), WithNodeParser(parse))
+	if !errors.Is(err, errUnknown) {
+		t.Errorf("Expected the parser error to be wrapped, got %v", err)
+	}
+	if err != nil && err.Error() != `graph: dot: line 2: cannot parse node "a": unknown node` {
+		t.Errorf("Unexpected error message %q", err.Error())
+	}
+}
+
+// parallelGraph returns a multigraph with parallel edges between 1 and 2, two loops at 3 and an isolated node.
+func parallelGraph(directed bool) *Graph[int] {
+	g := NewMultigraph[int](directed)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(1, 2, 2)
+	g.AddEdge(2, 1, 3)
+	g.AddEdge(3, 3, 4)
+	g.AddEdge(3, 3, 5)
+	g.AddNode(4)
+	return g
+}
+
+// assertSameMultigraph checks that two graphs are multigraphs with the same parallel edges, in the same order.
+func assertSameMultigraph(t *testing.T, expected, actual *Graph[int]) {
+	t.Helper()
+
+	assertSameGraph(t, expected, actual)
+	if !actual.IsMultigraph() {
+		t.Error("Expected a multigraph")
+	}
+	for _, pair := range [][2]int{{1, 2}, {2, 1}, {3, 3}} {
+		want, got := expected.EdgesBetween(pair[0], pair[1]), actual.EdgesBetween(pair[0], pair[1])
+		if len(want) != len(got) {
+			t.Errorf("Edges %v: expected %d, got %d", pair, len(want), len(got))
+			continue
+		}
+		for i := range want {
+			if want[i].Weight != got[i].Weight {
+				t.Errorf("Edges %v: expected weight %v at %d, got %v", pair, want[i].Weight, i, got[i].Weight)
+			}
+		}
+	}
+}
+
+// assertSameGraph checks that two graphs have the same directedness, nodes and weighted edges.
+func assertSameGraph[T comparable](t *testing.T, expected, actual *Graph[T]) {
+	t.Helper()
+
+	if expected.directed != actual.directed {
+		t.Errorf("Expected directed=%v, got %v", expected.directed, actual.directed)
+	}
+	if len(expected.Nodes()) != len(actual.Nodes()) {
+		t.Errorf("Expected %d nodes, got %d", len(expected.Nodes()), len(actual.Nodes()))
+	}
+	for _, v := range expected.Nodes() {
+		if !actual.HasNode(v) {
+			t.Errorf("Missing node %v", v)
+		}
+	}
+	if len(expected.Edges()) != len(actual.Edges()) {
+		t.Errorf("Expected %d edges, got %d", len(expected.Edges()), len(actual.Edges()))
+	}
+	for _, e := range expected.Edges() {
+		want, _ := expected.GetEdgeWeight(e[0], e[1])
+		got, ok := actual.GetEdgeWeight(e[0], e[1])
+		if !ok || got != want {
+			t.Errorf("Edge %v: expected weight %v, got %v (exists=%v)", e, want, got, ok)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package graph
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// EncodingOption configures how node values are written and read by the DOT and edge list codecs.
+type EncodingOption[T comparable] func(*encoding[T])
+
+type encoding[T comparable] struct {
+	id    func(T) string
+	label func(T) string
+	parse func(string) (T, error)
+}
+
+// WithNodeID sets the function used to format a node value as its identifier.
+// The default formats values with fmt.Sprint.
+func WithNodeID[T comparable](id func(T) string) EncodingOption[T] {
+	return func(e *encoding[T]) {
+		e.id = id
+	}
+}
+
+// WithNodeLabel sets a function producing a display label written alongside each node.
+func WithNodeLabel[T comparable](label func(T) string) EncodingOption[T] {
+	return func(e *encoding[T]) {
+		e.label = label
+	}
+}
+
+// WithNodeParser sets the function used to turn an identifier back into a node value.
+// The default returns strings unchanged and scans other types with fmt.Sscan.
+func WithNodeParser[T comparable](parse func(string) (T, error)) EncodingOption[T] {
+	return func(e *encoding[T]) {
+		e.parse = parse
+	}
+}
+
+func newEncoding[T comparable](opts []EncodingOption[T]) *encoding[T] {
+	e := &encoding[T]{
+		id: func(v T) string {
+			return fmt.Sprint(v)
+		},
+		parse: func(s string) (T, error) {
+			var v T
+			if p, ok := any(&v).(*string); ok {
+				*p = s
+				return v, nil
+			}
+			_, err := fmt.Sscan(s, &v)
+			return v, err
+		},
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// node parses an identifier, wrapping parse failures with the offending input.
+func (e *encoding[T]) node(s string) (T, error) {
+	v, err := e.parse(s)
+	if err != nil {
+		return v, fmt.Errorf("cannot parse node %q: %w", s, err)
+	}
+	return v, nil
+}
+
+// sortedNodes returns the node values ordered by their formatted identifiers so that output is stable.
+func (g *Graph[T]) sortedNodes(e *encoding[T]) ([]T, map[T]string) {
+	ids := make(map[T]string, len(g.nodes))
+	nodes := make([]T, 0, len(g.nodes))
+	for v := range g.nodes {
+		ids[v] = e.id(v)
+		nodes = append(nodes, v)
+	}
+	sort.Slice(
+		nodes, func(i, j int) bool {
+			return ids[nodes[i]] < ids[nodes[j]]
+		},
+	)
+	return nodes, ids
+}
+
+// sortedEdges returns the edges of the graph ordered by the identifiers of their endpoints.
+// Undirected edges are returned once.
+func (g *Graph[T]) sortedEdges(nodes []T, ids map[T]string) []*edge[T] {
+	var edges []*edge[T]
+	for _, from := range nodes {
+		n := g.nodes[from]
+		targets := make([]T, 0, len(n.edges))
+		for to := range n.edges {
+			if g.directed || ids[from] <= ids[to] {
+				targets = append(targets, to)
+			}
+		}
+		sort.Slice(
+			targets, func(i, j int) bool {
+				return ids[targets[i]] < ids[targets[j]]
+			},
+		)
+		for _, to := range targets {
+			for e := n.edges[to]; e != nil; e = e.next {
+				edges = append(edges, e)
+			}
+		}
+	}
+	return edges
+}
+
+type jsonEdge[T comparable] struct {
+	From   T       `json:"from"`
+	To     T       `json:"to"`
+	Weight float64 `json:"weight"`
+}
+
+type jsonGraph[T comparable] struct {
+	Directed   bool          `json:"directed"`
+	Multigraph bool          `json:"multigraph,omitempty"`
+	Nodes      []T           `json:"nodes"`
+	Edges      []jsonEdge[T] `json:"edges"`
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Node values are encoded with their own JSON representation. Node and edge attributes are not
+// encoded, since their values can be of any type, and edge IDs are assigned afresh when decoding.
+// It returns ErrNilGraph for a nil graph.
+func (g *Graph[T]) MarshalJSON() ([]byte, error) {
+	if g == nil {
+		return nil, ErrNilGraph
+	}
+
+	nodes, ids := g.sortedNodes(newEncoding[T](nil))
+	out := jsonGraph[T]{
+		Directed:   g.directed,
+		Multigraph: g.multi,
+		Nodes:      nodes,
+		Edges:      []jsonEdge[T]{},
+	}
+	for _, e := range g.sortedEdges(nodes, ids) {
+		out.Edges = append(out.Edges, jsonEdge[T]{From: e.from.value, To: e.to.value, Weight: e.weight})
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// Any existing nodes and edges are replaced. It returns ErrNilGraph for a nil graph.
+func (g *Graph[T]) UnmarshalJSON(data []byte) error {
+	if g == nil {
+		return ErrNilGraph
+	}
+
+	var in jsonGraph[T]
+	if err := json.Unmarshal(data, &in); err != nil {
+		return fmt.Errorf("graph: invalid JSON: %w", err)
+	}
+
+	*g = *New[T](in.Directed)
+	g.multi = in.Multigraph
+	for _, v := range in.Nodes {
+		g.AddNode(v)
+	}
+	for _, e := range in.Edges {
+		g.AddEdge(e.From, e.To, e.Weight)
+	}
+	return nil
+}
+
+// WriteEdgeList writes the graph as CSV records of the form "from,to,weight".
+// Nodes without edges are written as single-field records so they survive a round trip.
+func (g *Graph[T]) WriteEdgeList(w io.Writer, opts ...EncodingOption[T]) error {
+	enc := newEncoding(opts)
+	nodes, ids := g.sortedNodes(enc)
+	cw := csv.NewWriter(w)
+
+	for _, v := range nodes {
+		if len(g.nodes[v].edges) == 0 && len(g.nodes[v].incoming) == 0 {
+			if err := cw.Write([]string{ids[v]}); err != nil {
+				return err
+			}
+		}
+	}
+	for _, e := range g.sortedEdges(nodes, ids) {
+		record := []string{
+			ids[e.from.value],
+			ids[e.to.value],
+			strconv.FormatFloat(e.weight, 'g', -1, 64),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadEdgeList builds a graph from CSV records written by WriteEdgeList.
+// Records may be "node", "from,to" (weight 1) or "from,to,weight".
+// A repeated edge makes the result a multigraph, so parallel edges survive a round trip.
+func ReadEdgeList[T comparable](r io.Reader, directed bool, opts ...EncodingOption[T]) (*Graph[T], error) {
+	enc := newEncoding(opts)
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	g := New[T](directed)
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return g, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := cr.FieldPos(0)
+		switch len(record) {
+		case 1:
+			v, err := enc.node(record[0])
+			if err != nil {
+				return nil, fmt.Errorf("graph: line %d: %w", line, err)
+			}
+			g.AddNode(v)
+		case 2, 3:
+			from, err := enc.node(record[0])
+			if err != nil {
+				return nil, fmt.Errorf("graph: line %d: %w", line, err)
+			}
+			to, err := enc.node(record[1])
+			if err != nil {
+				return nil, fmt.Errorf("graph: line %d: %w", line, err)
+			}
+			weight := 1.0
+			if len(record) == 3 {
+				if weight, err = strconv.ParseFloat(record[2], 64); err != nil {
+					return nil, fmt.Errorf("graph: invalid weight %q: %w", record[2], err)
+				}
+			}
+			if !g.AddEdge(from, to, weight) {
+				g.multi = true
+				g.AddEdge(from, to, weight)
+			}
+		default:
+			return nil, fmt.Errorf("graph: line %d: expected 1 to 3 fields, got %d", line, len(record))
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type city struct {
+	Name string `json:"name"`
+	Code int    `json:"code"`
+}
+
+func TestJSON_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		directed bool
+	}{
+		{"undirected", false},
+		{"directed", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				g := New[city](tt.directed)
+				ams, lhr, jfk := city{"Amsterdam", 1}, city{"London", 2}, city{"New York", 3}
+				g.AddEdge(ams, lhr, 1.5)
+				g.AddEdge(lhr, jfk, 7)
+				g.AddNode(city{"Nowhere", 0})
+
+				data, err := json.Marshal(g)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+
+				decoded := New[city](false)
+				decoded.AddNode(city{"Stale", 9})
+				if err := json.Unmarshal(data, decoded); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if decoded.HasNode(city{"Stale", 9}) {
+					t.Error("UnmarshalJSON should replace existing nodes")
+				}
+				assertSameGraph(t, g, decoded)
+			},
+		)
+	}
+}
+
+func TestJSON_Format(t *testing.T) {
+	g := New[string](true)
+	g.AddEdge("a", "b", 2)
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `{"directed":true,"nodes":["a","b"],"edges":[{"from":"a","to":"b","weight":2}]}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	var zero Graph[string]
+	if err := json.Unmarshal(data, &zero); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !zero.HasEdge("a", "b") {
+		t.Error("Unmarshal into zero Graph should initialize it")
+	}
+}
+
+func TestJSON_Errors(t *testing.T) {
+	var nilGraph *Graph[string]
+	if _, err := nilGraph.MarshalJSON(); !errors.Is(err, ErrNilGraph) {
+		t.Errorf("Expected ErrNilGraph when marshaling, got %v", err)
+	}
+	if err := nilGraph.UnmarshalJSON([]byte(`{}`)); !errors.Is(err, ErrNilGraph) {
+		t.Errorf("Expected ErrNilGraph when unmarshaling, got %v", err)
+	}
+
+	g := New[string](false)
+	if err := g.UnmarshalJSON([]byte(`{"nodes": 1}`)); err == nil || !strings.HasPrefix(err.Error(), "graph: ") {
+		t.Errorf("Expected a graph error for invalid JSON, got %v", err)
+	}
+}
+
+func TestJSON_DropsAttributes(t *testing.T) {
+	label := NewAttr[string]("label")
+	g := New[string](true)
+	g.AddNodeWith("a", label.With("node"))
+	g.AddEdgeWith("a", "b", 2, label.With("edge"))
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	decoded := New[string](true)
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	assertSameGraph(t, g, decoded)
+	if attrs := decoded.NodeAttrs("a"); attrs.Len() != 0 {
+		t.Errorf("Expected node attributes to be dropped, got %d", attrs.Len())
+	}
+	if attrs := decoded.EdgeAttrs("a", "b"); attrs.Len() != 0 {
+		t.Errorf("Expected edge attributes to be dropped, got %d", attrs.Len())
+	}
+}
+
+func TestEdgeList_RoundTrip(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(1, 2, 0.25)
+	g.AddEdge(2, 1, 3)
+	g.AddEdge(2, 3, 1)
+	g.AddNode(42)
+
+	var buf bytes.Buffer
+	if err := g.WriteEdgeList(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "42\n1,2,0.25\n2,1,3\n2,3,1\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+
+	decoded, err := ReadEdgeList[int](&buf, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertSameGraph(t, g, decoded)
+}
+
+func TestEdgeList_Multigraph(t *testing.T) {
+	for _, directed := range []bool{false, true} {
+		g := parallelGraph(directed)
+
+		var buf bytes.Buffer
+		if err := g.WriteEdgeList(&buf); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		decoded, err := ReadEdgeList[int](&buf, directed)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		assertSameMultigraph(t, g, decoded)
+	}
+}
+
+func TestReadEdgeList(t *testing.T) {
+	src := "a,b\n\"c, d\",a,2.5\ne\n"
+	g, err := ReadEdgeList[string](strings.NewReader(src), false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if w, ok := g.GetEdgeWeight("b", "a"); !ok || w != 1 {
+		t.Errorf("Expected undirected edge b-a with default weight 1, got %v, %v", w, ok)
+	}
+	if w, ok := g.GetEdgeWeight("c, d", "a"); !ok || w != 2.5 {
+		t.Errorf("Expected edge with quoted node, got %v, %v", w, ok)
+	}
+	if !g.HasNode("e") {
+		t.Error("Expected isolated node e")
+	}
+
+	invalid := []string{"a,b,c,d\n", "a,b,heavy\n"}
+	for _, src := range invalid {
+		if _, err := ReadEdgeList[string](strings.NewReader(src), false); err == nil {
+			t.Errorf("Expected error for %q", src)
+		}
+	}
+
+	errUnknown := errors.New("unknown node")
+	_, err = ReadEdgeList(
+		strings.NewReader("1,2\nx,2\n"), false,
+		WithNodeParser(
+			func(s string) (string, error) {
+				if s == "x" {
+					return "", errUnknown
+				}
+				return s, nil
+			},
+		),
+	)
+	if !errors.Is(err, errUnknown) || err.Error() != `graph: line 2: cannot parse node "x": unknown node` {
+		t.Errorf("Expected the parser error to be wrapped with its line, got %v", err)
+	}
+
+	parse := func(s string) (city, error) {
+		return city{Name: s}, nil
+	}
+	cities, err := ReadEdgeList(strings.NewReader("Oslo,Bergen\n"), false, WithNodeParser(parse))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !cities.HasEdge(city{Name: "Bergen"}, city{Name: "Oslo"}) {
+		t.Error("Expected custom parser to build city nodes")
+	}
+}
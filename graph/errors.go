@@ -0,0 +1,32 @@
+package graph
+
+import "errors"
+
+var (
+	// ErrNilGraph is returned when a nil *Graph is encoded, decoded into or combined with another graph.
+	ErrNilGraph = errors.New("graph: nil graph")
+
+	// ErrNodeNotFound is returned when an algorithm is given a node that is not in the graph.
+	ErrNodeNotFound = errors.New("graph: node not found")
+
+	// ErrSameSourceSink is returned when the source and sink of a flow are the same node.
+	ErrSameSourceSink = errors.New("graph: source and sink must be different nodes")
+
+	// ErrNegativeCapacity is returned when a flow network contains an edge with negative weight.
+	ErrNegativeCapacity = errors.New("graph: edge capacity must not be negative")
+
+	// ErrNegativeWeight is returned by algorithms that require non-negative edge weights.
+	ErrNegativeWeight = errors.New("graph: edge weight must not be negative")
+
+	// ErrNoConvergence is returned when an iterative algorithm does not converge within its iteration limit.
+	ErrNoConvergence = errors.New("graph: algorithm did not converge")
+
+	// ErrDirectionMismatch is returned when combining a directed graph with an undirected one.
+	ErrDirectionMismatch = errors.New("graph: graphs must both be directed or both undirected")
+
+	// ErrNoPath is returned when the target node cannot be reached from the source node.
+	ErrNoPath = errors.New("graph: no path between the nodes")
+
+	// ErrNotEulerian is returned when no Eulerian path or circuit exists.
+	ErrNotEulerian = errors.New("graph: no Eulerian path exists")
+)
@@ -0,0 +1,128 @@
+package graph
+
+// EulerianCircuit returns a closed walk that uses every edge exactly once, as the sequence of
+// nodes visited, starting and ending at the same node. Parallel edges and self-loops are each
+// traversed once. It returns ErrNotEulerian if the edges are not connected or some node has odd
+// degree (or, for directed graphs, different in-degree and out-degree). A graph without edges
+// has an empty circuit.
+func (g *Graph[T]) EulerianCircuit() ([]T, error) {
+	return g.frozen().EulerianCircuit()
+}
+
+// EulerianPath returns a walk that uses every edge exactly once, as the sequence of nodes visited.
+// The walk is closed if the graph has an Eulerian circuit. It returns ErrNotEulerian if the edges
+// are not connected or more than two nodes have odd degree (or, for directed graphs, the degrees
+// do not allow a start and an end node).
+func (g *Graph[T]) EulerianPath() ([]T, error) {
+	return g.frozen().EulerianPath()
+}
+
+// EulerianCircuit returns a closed walk that uses every edge exactly once.
+// See Graph.EulerianCircuit for details.
+func (f *Frozen[T]) EulerianCircuit() ([]T, error) {
+	start, circuit, ok := f.eulerianStart()
+	if !ok || !circuit {
+		return nil, ErrNotEulerian
+	}
+	return f.hierholzer(start)
+}
+
+// EulerianPath returns a walk that uses every edge exactly once.
+// See Graph.EulerianPath for details.
+func (f *Frozen[T]) EulerianPath() ([]T, error) {
+	start, _, ok := f.eulerianStart()
+	if !ok {
+		return nil, ErrNotEulerian
+	}
+	return f.hierholzer(start)
+}
+
+// eulerianStart checks the degree conditions for an Eulerian path and returns the node to start from,
+// or -1 if there are no edges. circuit is true if the path can be closed.
+func (f *Frozen[T]) eulerianStart() (start int, circuit, ok bool) {
+	start = -1
+	odd := 0
+	for u := range f.values {
+		out := f.offsets[u+1] - f.offsets[u]
+		if out == 0 && (!f.directed || f.inOffsets[u+1] == f.inOffsets[u]) {
+			continue
+		}
+
+		if f.directed {
+			switch balance := out - (f.inOffsets[u+1] - f.inOffsets[u]); {
+			case balance == 1:
+				odd++
+				start = u
+			case balance == -1:
+				odd++
+			case balance != 0:
+				return 0, false, false
+			case start < 0 && out > 0:
+				start = u
+			}
+			continue
+		}
+
+		degree := out
+		for a := f.offsets[u]; a < f.offsets[u+1]; a++ {
+			if f.targets[a] == u {
+				degree++ // Self-loops are stored once but count twice
+			}
+		}
+		if degree%2 == 1 {
+			if odd == 0 {
+				start = u // Start from the first odd node, if any
+			}
+			odd++
+		} else if start < 0 {
+			start = u
+		}
+	}
+
+	if odd > 2 || (f.directed && odd == 1) {
+		return 0, false, false
+	}
+	return start, odd == 0, true
+}
+
+// hierholzer builds an Eulerian path from start with an explicit stack,
+// and fails if some edges are not reachable.
+func (f *Frozen[T]) hierholzer(start int) ([]T, error) {
+	if start < 0 {
+		return nil, nil
+	}
+
+	used := make(map[int]bool, f.edges)
+	next := make([]int, len(f.values))
+	for u := range next {
+		next[u] = f.offsets[u]
+	}
+
+	var path []int
+	stack := []int{start}
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		for next[u] < f.offsets[u+1] && used[f.ids[next[u]]] {
+			next[u]++
+		}
+		if next[u] == f.offsets[u+1] {
+			stack = stack[:len(stack)-1]
+			path = append(path, u)
+			continue
+		}
+		a := next[u]
+		used[f.ids[a]] = true
+		stack = append(stack, f.targets[a])
+	}
+
+	if len(path) != f.edges+1 {
+		return nil, ErrNotEulerian // The edges form more than one component
+	}
+
+	// Nodes were collected in reverse
+	walk := make([]T, len(path))
+	for i, u := range path {
+		walk[len(path)-1-i] = f.values[u]
+	}
+	return walk, nil
+}
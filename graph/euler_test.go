@@ -0,0 +1,117 @@
+package graph
+
+import "testing"
+
+// assertEulerian checks that walk uses every edge of g exactly once.
+func assertEulerian[T comparable](t *testing.T, g *Graph[T], walk []T, closed bool) {
+	t.Helper()
+	edges := len(g.Edges())
+	if len(walk) != edges+1 {
+		t.Fatalf("Expected a walk over %d edges, got %v", edges, walk)
+	}
+	if closed && walk[0] != walk[len(walk)-1] {
+		t.Errorf("Expected a closed walk, got %v", walk)
+	}
+
+	remaining := make(map[[2]T]int)
+	for _, e := range g.Edges() {
+		remaining[e]++
+	}
+	for i := 1; i < len(walk); i++ {
+		step := [2]T{walk[i-1], walk[i]}
+		if remaining[step] == 0 && !g.directed {
+			step = [2]T{walk[i], walk[i-1]}
+		}
+		if remaining[step] == 0 {
+			t.Fatalf("Step %v of %v is not an unused edge", step, walk)
+		}
+		remaining[step]--
+	}
+}
+
+func TestEulerianCircuit(t *testing.T) {
+	// Two triangles sharing node 3, a self-loop and a pair of parallel edges
+	g := NewMultigraph[int](false)
+	for _, e := range [][2]int{{1, 2}, {2, 3}, {3, 1}, {3, 4}, {4, 5}, {5, 3}, {4, 4}, {5, 6}, {6, 5}} {
+		g.AddEdge(e[0], e[1], 1)
+	}
+	g.AddNode(9) // Isolated nodes are allowed
+
+	walk, err := g.EulerianCircuit()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertEulerian(t, g, walk, true)
+
+	directed := cycleGraph(5, true)
+	directed.AddEdge(0, 2, 1)
+	directed.AddEdge(2, 0, 1)
+	walk, err = directed.EulerianCircuit()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertEulerian(t, directed, walk, true)
+
+	if walk, err := New[int](false).EulerianCircuit(); err != nil || len(walk) != 0 {
+		t.Errorf("Expected an empty circuit, got %v, %v", walk, err)
+	}
+}
+
+func TestEulerianPath(t *testing.T) {
+	// The house of Santa Claus: nodes 1 and 2 have odd degree
+	g := New[int](false)
+	for _, e := range [][2]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}, {3, 5}, {4, 5}} {
+		g.AddEdge(e[0], e[1], 1)
+	}
+	walk, err := g.EulerianPath()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertEulerian(t, g, walk, false)
+	if ends := [2]int{walk[0], walk[len(walk)-1]}; ends != [2]int{1, 2} && ends != [2]int{2, 1} {
+		t.Errorf("Expected the path to connect the odd nodes, got %v", walk)
+	}
+	if _, err := g.EulerianCircuit(); err != ErrNotEulerian {
+		t.Errorf("Expected ErrNotEulerian for a circuit, got %v", err)
+	}
+
+	directed := New[string](true)
+	directed.AddEdge("a", "b", 1)
+	directed.AddEdge("b", "c", 1)
+	directed.AddEdge("c", "a", 1)
+	directed.AddEdge("a", "d", 1)
+	walk2, err := directed.EulerianPath()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertEulerian(t, directed, walk2, false)
+	if walk2[0] != "a" || walk2[len(walk2)-1] != "d" {
+		t.Errorf("Expected a path from a to d, got %v", walk2)
+	}
+}
+
+func TestEulerian_Errors(t *testing.T) {
+	star := New[int](false)
+	star.AddEdge(0, 1, 1)
+	star.AddEdge(0, 2, 1)
+	star.AddEdge(0, 3, 1)
+
+	disconnected := cycleGraph(3, false)
+	disconnected.AddEdge(10, 11, 1)
+	disconnected.AddEdge(11, 12, 1)
+	disconnected.AddEdge(12, 10, 1)
+
+	unbalanced := New[int](true)
+	unbalanced.AddEdge(1, 2, 1)
+	unbalanced.AddEdge(1, 3, 1)
+
+	for name, g := range map[string]*Graph[int]{
+		"four odd nodes": star,
+		"disconnected":   disconnected,
+		"unbalanced":     unbalanced,
+	} {
+		if _, err := g.EulerianPath(); err != ErrNotEulerian {
+			t.Errorf("%s: expected ErrNotEulerian, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+package graph
+
+import "math"
+
+// flowEpsilon is the smallest residual capacity treated as non-zero.
+const flowEpsilon = 1e-9
+
+// FlowResult describes a maximum flow and the minimum cut it induces.
+type FlowResult[T comparable] struct {
+	// Value is the total flow from source to sink. It equals the capacity of the minimum cut.
+	Value float64
+
+	// Flow holds the flow carried by every edge with positive flow,
+	// keyed by {from, to} in the direction the flow travels.
+	Flow map[[2]T]float64
+
+	// SourceSide holds the nodes reachable from the source in the residual graph.
+	SourceSide []T
+
+	// SinkSide holds all remaining nodes, including the sink.
+	SinkSide []T
+
+	// CutEdges holds the saturated edges leading from SourceSide to SinkSide.
+	CutEdges [][2]T
+}
+
+// flowNetwork is an int-indexed residual network.
+// Arcs are stored in pairs so that arc a and arc a^1 are reverses of each other.
+type flowNetwork struct {
+	adj  [][]int
+	to   []int
+	cap  []float64
+	flow []float64
+}
+
+// flowEdge links an edge of the original graph to its forward arc.
+type flowEdge struct {
+	from, to int
+	arc      int
+}
+
+func newFlowNetwork(size int) *flowNetwork {
+	return &flowNetwork{adj: make([][]int, size)}
+}
+
+// addArc adds an arc u->v with capacity c and its reverse with capacity rc, returning the index of u->v.
+func (n *flowNetwork) addArc(u, v int, c, rc float64) int {
+	a := len(n.to)
+	n.to = append(n.to, v, u)
+	n.cap = append(n.cap, c, rc)
+	n.flow = append(n.flow, 0, 0)
+	n.adj[u] = append(n.adj[u], a)
+	n.adj[v] = append(n.adj[v], a+1)
+	return a
+}
+
+func (n *flowNetwork) residual(a int) float64 {
+	return n.cap[a] - n.flow[a]
+}
+
+func (n *flowNetwork) push(a int, amount float64) {
+	n.flow[a] += amount
+	n.flow[a^1] -= amount
+}
+
+// edmondsKarp augments along shortest paths found by breadth-first search.
+func (n *flowNetwork) edmondsKarp(s, t int) float64 {
+	total := 0.0
+	parent := make([]int, len(n.adj))
+
+	for {
+		for i := range parent {
+			parent[i] = -1
+		}
+		queue := []int{s}
+		for len(queue) > 0 && parent[t] == -1 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, a := range n.adj[u] {
+				v := n.to[a]
+				if v != s && parent[v] == -1 && n.residual(a) > flowEpsilon {
+					parent[v] = a
+					queue = append(queue, v)
+				}
+			}
+		}
+		if parent[t] == -1 {
+			return total
+		}
+
+		bottleneck := math.Inf(1)
+		for v := t; v != s; v = n.to[parent[v]^1] {
+			bottleneck = math.Min(bottleneck, n.residual(parent[v]))
+		}
+		for v := t; v != s; v = n.to[parent[v]^1] {
+			n.push(parent[v], bottleneck)
+		}
+		total += bottleneck
+	}
+}
+
+// dinic repeatedly builds a level graph and saturates it with a blocking flow.
+func (n *flowNetwork) dinic(s, t int) float64 {
+	total := 0.0
+	level := make([]int, len(n.adj))
+	next := make([]int, len(n.adj))
+
+	for n.buildLevels(s, t, level) {
+		for i := range next {
+			next[i] = 0
+		}
+		for {
+			pushed := n.augment(s, t, math.Inf(1), level, next)
+			if pushed <= flowEpsilon {
+				break
+			}
+			total += pushed
+		}
+	}
+	return total
+}
+
+// buildLevels assigns BFS distances from s and reports whether t is reachable.
+func (n *flowNetwork) buildLevels(s, t int, level []int) bool {
+	for i := range level {
+		level[i] = -1
+	}
+	level[s] = 0
+	queue := []int{s}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, a := range n.adj[u] {
+			v := n.to[a]
+			if level[v] == -1 && n.residual(a) > flowEpsilon {
+				level[v] = level[u] + 1
+				queue = append(queue, v)
+			}
+		}
+	}
+	return level[t] != -1
+}
+
+// augment pushes up to limit units from u to t along the level graph.
+func (n *flowNetwork) augment(u, t int, limit float64, level, next []int) float64 {
+	if u == t {
+		return limit
+	}
+	for ; next[u] < len(n.adj[u]); next[u]++ {
+		a := n.adj[u][next[u]]
+		v := n.to[a]
+		r := n.residual(a)
+		if r <= flowEpsilon || level[v] != level[u]+1 {
+			continue
+		}
+		if pushed := n.augment(v, t, math.Min(limit, r), level, next); pushed > flowEpsilon {
+			n.push(a, pushed)
+			return pushed
+		}
+	}
+	return 0
+}
+
+// reachable marks the nodes reachable from s through arcs with residual capacity.
+func (n *flowNetwork) reachable(s int) []bool {
+	seen := make([]bool, len(n.adj))
+	seen[s] = true
+	queue := []int{s}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, a := range n.adj[u] {
+			v := n.to[a]
+			if !seen[v] && n.residual(a) > flowEpsilon {
+				seen[v] = true
+				queue = append(queue, v)
+			}
+		}
+	}
+	return seen
+}
+
+// EdmondsKarp computes a maximum flow from source to sink using edge weights as capacities.
+// Undirected edges may carry flow in either direction.
+func (g *Graph[T]) EdmondsKarp(source, sink T) (*FlowResult[T], error) {
+	return g.EdmondsKarpFunc(source, sink, EdgeWeight[T])
+}
+
+// EdmondsKarpFunc is like EdmondsKarp but reads capacities with the given WeightFunc.
+func (g *Graph[T]) EdmondsKarpFunc(source, sink T, capacity WeightFunc[T]) (*FlowResult[T], error) {
+	return g.frozen().EdmondsKarpFunc(source, sink, capacity)
+}
+
+// Dinic computes a maximum flow from source to sink using edge weights as capacities.
+// It is usually faster than EdmondsKarp on large or dense networks.
+func (g *Graph[T]) Dinic(source, sink T) (*FlowResult[T], error) {
+	return g.DinicFunc(source, sink, EdgeWeight[T])
+}
+
+// DinicFunc is like Dinic but reads capacities with the given WeightFunc.
+func (g *Graph[T]) DinicFunc(source, sink T, capacity WeightFunc[T]) (*FlowResult[T], error) {
+	return g.frozen().DinicFunc(source, sink, capacity)
+}
+
+// EdmondsKarp computes a maximum flow from source to sink using edge weights as capacities.
+func (f *Frozen[T]) EdmondsKarp(source, sink T) (*FlowResult[T], error) {
+	return f.EdmondsKarpFunc(source, sink, EdgeWeight[T])
+}
+
+// EdmondsKarpFunc is like EdmondsKarp but reads capacities with the given WeightFunc.
+func (f *Frozen[T]) EdmondsKarpFunc(source, sink T, capacity WeightFunc[T]) (*FlowResult[T], error) {
+	return f.maxFlow(source, sink, capacity, (*flowNetwork).edmondsKarp)
+}
+
+// Dinic computes a maximum flow from source to sink using Dinic's algorithm.
+func (f *Frozen[T]) Dinic(source, sink T) (*FlowResult[T], error) {
+	return f.DinicFunc(source, sink, EdgeWeight[T])
+}
+
+// DinicFunc is like Dinic but reads capacities with the given WeightFunc.
+func (f *Frozen[T]) DinicFunc(source, sink T, capacity WeightFunc[T]) (*FlowResult[T], error) {
+	return f.maxFlow(source, sink, capacity, (*flowNetwork).dinic)
+}
+
+func (f *Frozen[T]) maxFlow(
+	source, sink T,
+	capacity WeightFunc[T],
+	solve func(n *flowNetwork, s, t int) float64,
+) (*FlowResult[T], error) {
+	s, sourceExists := f.index[source]
+	t, sinkExists := f.index[sink]
+	if !sourceExists || !sinkExists {
+		return nil, ErrNodeNotFound
+	}
+	if source == sink {
+		return nil, ErrSameSourceSink
+	}
+
+	values := f.values
+	net := newFlowNetwork(len(values))
+	var edges []flowEdge
+	for u := range values {
+		for a := f.offsets[u]; a < f.offsets[u+1]; a++ {
+			v := f.targets[a]
+			if u == v || (!f.directed && u > v) {
+				continue
+			}
+			c := capacity(f.edgeView(u, a))
+			if c < 0 {
+				return nil, ErrNegativeCapacity
+			}
+			reverse := 0.0
+			if !f.directed {
+				reverse = c
+			}
+			edges = append(edges, flowEdge{from: u, to: v, arc: net.addArc(u, v, c, reverse)})
+		}
+	}
+
+	result := &FlowResult[T]{
+		Value: solve(net, s, t),
+		Flow:  make(map[[2]T]float64),
+	}
+
+	for _, e := range edges {
+		// Parallel edges of a multigraph add up to a single entry
+		if f := net.flow[e.arc]; f > flowEpsilon {
+			result.Flow[[2]T{values[e.from], values[e.to]}] += f
+		} else if f < -flowEpsilon {
+			result.Flow[[2]T{values[e.to], values[e.from]}] -= f
+		}
+	}
+
+	sourceSide := net.reachable(s)
+	for i, v := range values {
+		if sourceSide[i] {
+			result.SourceSide = append(result.SourceSide, v)
+		} else {
+			result.SinkSide = append(result.SinkSide, v)
+		}
+	}
+	for _, e := range edges {
+		switch {
+		case sourceSide[e.from] && !sourceSide[e.to]:
+			result.CutEdges = append(result.CutEdges, [2]T{values[e.from], values[e.to]})
+		case !f.directed && sourceSide[e.to] && !sourceSide[e.from]:
+			result.CutEdges = append(result.CutEdges, [2]T{values[e.to], values[e.from]})
+		}
+	}
+
+	return result, nil
+}
+
+// HopcroftKarp returns a maximum matching between the nodes in left and their neighbors.
+// The result maps each matched node of left to its partner. Edges between two nodes of
+// left are ignored. The matching is found with Dinic's algorithm on a unit-capacity
+// network, which performs the same phases as Hopcroft-Karp and runs in O(E√V).
+func (g *Graph[T]) HopcroftKarp(left []T) (map[T]T, error) {
+	return g.frozen().HopcroftKarp(left)
+}
+
+// HopcroftKarp returns a maximum matching between the nodes in left and their neighbors.
+// See Graph.HopcroftKarp for details.
+func (f *Frozen[T]) HopcroftKarp(left []T) (map[T]T, error) {
+	values := f.values
+	isLeft := make([]bool, len(values))
+	for _, v := range left {
+		i, exists := f.index[v]
+		if !exists {
+			return nil, ErrNodeNotFound
+		}
+		isLeft[i] = true
+	}
+
+	// Nodes len(values) and len(values)+1 are the virtual source and sink.
+	s, t := len(values), len(values)+1
+	net := newFlowNetwork(len(values) + 2)
+	var edges []flowEdge
+	for i := range values {
+		if isLeft[i] {
+			net.addArc(s, i, 1, 0)
+			for _, j := range f.Successors(i) {
+				if !isLeft[j] {
+					edges = append(edges, flowEdge{from: i, to: j, arc: net.addArc(i, j, 1, 0)})
+				}
+			}
+		} else {
+			net.addArc(i, t, 1, 0)
+		}
+	}
+
+	net.dinic(s, t)
+
+	matching := make(map[T]T)
+	for _, e := range edges {
+		if net.flow[e.arc] > flowEpsilon {
+			matching[values[e.from]] = values[e.to]
+		}
+	}
+	return matching, nil
+}
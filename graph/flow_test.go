@@ -0,0 +1,206 @@
+package graph
+
+import (
+	"math"
+	"testing"
+)
+
+// clrsNetwork builds the classic flow network from CLRS with a maximum flow of 23.
+func clrsNetwork() *Graph[string] {
+	g := New[string](true)
+	g.AddEdge("s", "v1", 16)
+	g.AddEdge("s", "v2", 13)
+	g.AddEdge("v1", "v3", 12)
+	g.AddEdge("v2", "v1", 4)
+	g.AddEdge("v2", "v4", 14)
+	g.AddEdge("v3", "v2", 9)
+	g.AddEdge("v3", "t", 20)
+	g.AddEdge("v4", "v3", 7)
+	g.AddEdge("v4", "t", 4)
+	return g
+}
+
+func TestMaxFlow(t *testing.T) {
+	algorithms := []struct {
+		name string
+		run  func(g *Graph[string], s, t string) (*FlowResult[string], error)
+	}{
+		{"EdmondsKarp", (*Graph[string]).EdmondsKarp},
+		{"Dinic", (*Graph[string]).Dinic},
+	}
+
+	for _, alg := range algorithms {
+		t.Run(
+			alg.name, func(t *testing.T) {
+				g := clrsNetwork()
+				result, err := alg.run(g, "s", "t")
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if math.Abs(result.Value-23) > 1e-9 {
+					t.Errorf("Expected max flow 23, got %v", result.Value)
+				}
+
+				// Flow must respect capacities and be conserved at inner nodes
+				balance := make(map[string]float64)
+				for e, f := range result.Flow {
+					capacity, ok := g.GetEdgeWeight(e[0], e[1])
+					if !ok {
+						t.Errorf("Flow on non-existent edge %v", e)
+					}
+					if f > capacity+1e-9 {
+						t.Errorf("Flow %v exceeds capacity %v on edge %v", f, capacity, e)
+					}
+					balance[e[0]] -= f
+					balance[e[1]] += f
+				}
+				for node, b := range balance {
+					if node != "s" && node != "t" && math.Abs(b) > 1e-9 {
+						t.Errorf("Flow not conserved at %s: %v", node, b)
+					}
+				}
+
+				// The cut separates source and sink and its capacity equals the flow
+				sourceSide := make(map[string]bool)
+				for _, v := range result.SourceSide {
+					sourceSide[v] = true
+				}
+				if !sourceSide["s"] || sourceSide["t"] {
+					t.Error("Min cut should separate source and sink")
+				}
+				if len(result.SourceSide)+len(result.SinkSide) != len(g.Nodes()) {
+					t.Error("Cut sides should partition all nodes")
+				}
+				cutCapacity := 0.0
+				for _, e := range result.CutEdges {
+					w, _ := g.GetEdgeWeight(e[0], e[1])
+					cutCapacity += w
+				}
+				if math.Abs(cutCapacity-result.Value) > 1e-9 {
+					t.Errorf("Cut capacity %v does not match flow %v", cutCapacity, result.Value)
+				}
+			},
+		)
+	}
+}
+
+func TestMaxFlow_Undirected(t *testing.T) {
+	g := New[int](false)
+	g.AddEdge(1, 2, 3)
+	g.AddEdge(1, 3, 2)
+	g.AddEdge(3, 2, 1)
+	g.AddEdge(2, 4, 2)
+	g.AddEdge(3, 4, 3)
+
+	for name, run := range map[string]func(s, t int) (*FlowResult[int], error){
+		"EdmondsKarp": g.EdmondsKarp,
+		"Dinic":       g.Dinic,
+	} {
+		result, err := run(1, 4)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if math.Abs(result.Value-5) > 1e-9 {
+			t.Errorf("%s: expected max flow 5, got %v", name, result.Value)
+		}
+		if f := result.Flow[[2]int{2, 3}]; math.Abs(f-1) > 1e-9 {
+			t.Errorf("%s: expected flow 1 from 2 to 3 against insertion order, got %v", name, f)
+		}
+	}
+}
+
+func TestMaxFlow_Disconnected(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(1, 2, 5)
+	g.AddNode(3)
+
+	result, err := g.Dinic(1, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Value != 0 {
+		t.Errorf("Expected zero flow, got %v", result.Value)
+	}
+	if len(result.CutEdges) != 0 {
+		t.Errorf("Expected no cut edges, got %v", result.CutEdges)
+	}
+}
+
+func TestMaxFlow_Errors(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(1, 2, 1)
+
+	if _, err := g.EdmondsKarp(1, 5); err != ErrNodeNotFound {
+		t.Errorf("Expected ErrNodeNotFound, got %v", err)
+	}
+	if _, err := g.Dinic(1, 1); err != ErrSameSourceSink {
+		t.Errorf("Expected ErrSameSourceSink, got %v", err)
+	}
+
+	g.AddEdge(2, 3, -1)
+	if _, err := g.Dinic(1, 3); err != ErrNegativeCapacity {
+		t.Errorf("Expected ErrNegativeCapacity, got %v", err)
+	}
+}
+
+func TestHopcroftKarp(t *testing.T) {
+	g := New[string](false)
+	edges := [][2]string{
+		{"a", "1"}, {"a", "2"},
+		{"b", "1"},
+		{"c", "2"}, {"c", "3"},
+		{"d", "3"},
+	}
+	for _, e := range edges {
+		g.AddEdge(e[0], e[1], 1)
+	}
+
+	matching, err := g.HopcroftKarp([]string{"a", "b", "c", "d"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(matching) != 3 {
+		t.Errorf("Expected matching of size 3, got %d: %v", len(matching), matching)
+	}
+
+	used := make(map[string]bool)
+	for l, r := range matching {
+		if !g.HasEdge(l, r) {
+			t.Errorf("Matched pair %s-%s is not an edge", l, r)
+		}
+		if used[r] {
+			t.Errorf("Node %s matched twice", r)
+		}
+		used[r] = true
+	}
+
+	if _, err := g.HopcroftKarp([]string{"x"}); err != ErrNodeNotFound {
+		t.Errorf("Expected ErrNodeNotFound, got %v", err)
+	}
+}
+
+func TestMaxFlow_WeightFunc(t *testing.T) {
+	type link struct {
+		Bandwidth float64
+	}
+
+	linkAttr := NewAttr[link]("link")
+	g := New[string](true)
+	g.AddEdgeWith("s", "a", 1, linkAttr.With(link{Bandwidth: 10}))
+	g.AddEdgeWith("a", "t", 1, linkAttr.With(link{Bandwidth: 4}))
+	g.AddEdge("s", "t", 1)
+
+	bandwidth := WeightFrom[string](linkAttr, func(l link) float64 { return l.Bandwidth })
+	for name, run := range map[string]func(s, t string, w WeightFunc[string]) (*FlowResult[string], error){
+		"EdmondsKarpFunc": g.EdmondsKarpFunc,
+		"DinicFunc":       g.DinicFunc,
+	} {
+		result, err := run("s", "t", bandwidth)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if math.Abs(result.Value-5) > 1e-9 {
+			t.Errorf("%s: expected max flow 5, got %v", name, result.Value)
+		}
+	}
+}
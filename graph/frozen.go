@@ -0,0 +1,607 @@
+package graph
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/idsulik/go-collections/v3/iterator"
+)
+
+// Frozen is an immutable graph stored in compressed sparse row (CSR) form.
+// Nodes are numbered from 0 to NodeCount()-1 and the outgoing edges of every node
+// are kept contiguously, sorted by target index, which makes traversals cache-friendly
+// and uses a fraction of the memory of a Graph.
+type Frozen[T comparable] struct {
+	directed bool
+	multi    bool
+	nextID   int // Restored by Thaw so that new edges keep getting fresh IDs
+
+	values    []T
+	index     map[T]int
+	nodeAttrs []Attrs // nil if no node has attributes
+
+	// The outgoing edges of node i are stored at positions offsets[i] to offsets[i+1]-1.
+	// Undirected edges are stored in both rows, except self-loops which are stored once.
+	offsets   []int
+	targets   []int
+	weights   []float64
+	ids       []int
+	edgeAttrs []Attrs // nil if no edge has attributes
+	edges     int
+
+	// For directed graphs, the incoming edges of node i are stored at positions
+	// inOffsets[i] to inOffsets[i+1]-1 as their source and position in the outgoing arrays.
+	inOffsets []int
+	inSources []int
+	inArcs    []int
+}
+
+// Freeze returns an immutable copy of the graph in compressed sparse row form.
+// Node indices are assigned in an unspecified order; use Index and Value to map between them.
+// Edge IDs, weights and node and edge attributes are preserved.
+func (g *Graph[T]) Freeze() *Frozen[T] {
+	n := len(g.nodes)
+	f := &Frozen[T]{
+		directed: g.directed,
+		multi:    g.multi,
+		nextID:   g.nextID,
+		values:   make([]T, 0, n),
+		index:    make(map[T]int, n),
+		offsets:  make([]int, n+1),
+	}
+	for value, node := range g.nodes {
+		f.index[value] = len(f.values)
+		f.values = append(f.values, value)
+		if node.attrs.Len() > 0 {
+			if f.nodeAttrs == nil {
+				f.nodeAttrs = make([]Attrs, n)
+			}
+			f.nodeAttrs[f.index[value]] = node.attrs
+		}
+	}
+
+	type rowEntry struct {
+		target int
+		edge   *edge[T]
+	}
+	var row []rowEntry
+	// Edge attributes are only stored if some edge has them
+	hasAttrs := false
+	for _, e := range g.edges {
+		if e.attrs.Len() > 0 {
+			hasAttrs = true
+			break
+		}
+	}
+	for u, value := range f.values {
+		row = row[:0]
+		for to, head := range g.nodes[value].edges {
+			for e := head; e != nil; e = e.next {
+				row = append(row, rowEntry{target: f.index[to], edge: e})
+			}
+		}
+		sort.Slice(
+			row, func(i, j int) bool {
+				if row[i].target != row[j].target {
+					return row[i].target < row[j].target
+				}
+				return row[i].edge.id < row[j].edge.id
+			},
+		)
+
+		for _, entry := range row {
+			f.targets = append(f.targets, entry.target)
+			f.weights = append(f.weights, entry.edge.weight)
+			f.ids = append(f.ids, entry.edge.id)
+			if hasAttrs {
+				f.edgeAttrs = append(f.edgeAttrs, entry.edge.attrs)
+			}
+			if f.directed || entry.target == u {
+				f.edges++
+			}
+		}
+		f.offsets[u+1] = len(f.targets)
+	}
+	if !f.directed {
+		// Every edge except self-loops was stored twice
+		f.edges += (len(f.targets) - f.edges) / 2
+	}
+
+	if f.directed {
+		f.buildIncoming()
+	}
+	return f
+}
+
+// frozenCache holds the snapshot the algorithms of a Graph run on. It is built on first use and
+// dropped whenever the graph changes, so repeated queries on an unchanged graph share one snapshot.
+type frozenCache[T comparable] struct {
+	mu sync.Mutex // Guards f, so that concurrent readers build the snapshot once
+	f  *Frozen[T]
+}
+
+// frozen returns the cached snapshot of the graph, building it if the graph changed since the last call.
+func (g *Graph[T]) frozen() *Frozen[T] {
+	g.cache.mu.Lock()
+	defer g.cache.mu.Unlock()
+	if g.cache.f == nil {
+		g.cache.f = g.Freeze()
+	}
+	return g.cache.f
+}
+
+// changed drops the cached snapshot. Every method that modifies the graph must call it.
+func (g *Graph[T]) changed() {
+	g.cache.mu.Lock()
+	g.cache.f = nil
+	g.cache.mu.Unlock()
+}
+
+// buildIncoming fills the incoming edge arrays of a directed graph with a counting sort on targets.
+func (f *Frozen[T]) buildIncoming() {
+	n := len(f.values)
+	f.inOffsets = make([]int, n+1)
+	for _, v := range f.targets {
+		f.inOffsets[v+1]++
+	}
+	for i := 0; i < n; i++ {
+		f.inOffsets[i+1] += f.inOffsets[i]
+	}
+
+	f.inSources = make([]int, len(f.targets))
+	f.inArcs = make([]int, len(f.targets))
+	next := append([]int(nil), f.inOffsets[:n]...)
+	for u := 0; u < n; u++ {
+		for a := f.offsets[u]; a < f.offsets[u+1]; a++ {
+			v := f.targets[a]
+			f.inSources[next[v]] = u
+			f.inArcs[next[v]] = a
+			next[v]++
+		}
+	}
+}
+
+// Thaw returns a mutable Graph with the same nodes, edges, IDs and attributes.
+func (f *Frozen[T]) Thaw() *Graph[T] {
+	g := New[T](f.directed)
+	g.multi = f.multi
+	for i, value := range f.values {
+		g.AddNode(value)
+		if f.nodeAttrs != nil {
+			g.nodes[value].attrs = f.nodeAttrs[i]
+		}
+	}
+
+	// Re-add edges in ID order so that parallel edges keep their order
+	type arcRef struct{ from, arc int }
+	arcs := make([]arcRef, 0, f.edges)
+	for u := range f.values {
+		for a := f.offsets[u]; a < f.offsets[u+1]; a++ {
+			if f.directed || u <= f.targets[a] {
+				arcs = append(arcs, arcRef{from: u, arc: a})
+			}
+		}
+	}
+	sort.Slice(
+		arcs, func(i, j int) bool {
+			return f.ids[arcs[i].arc] < f.ids[arcs[j].arc]
+		},
+	)
+	for _, ref := range arcs {
+		g.insertEdge(f.edgeView(ref.from, ref.arc))
+	}
+	g.nextID = f.nextID
+	return g
+}
+
+// IsDirected returns true if the graph is directed.
+func (f *Frozen[T]) IsDirected() bool {
+	return f.directed
+}
+
+// IsMultigraph returns true if the graph was frozen from a multigraph.
+func (f *Frozen[T]) IsMultigraph() bool {
+	return f.multi
+}
+
+// NodeCount returns the number of nodes.
+func (f *Frozen[T]) NodeCount() int {
+	return len(f.values)
+}
+
+// EdgeCount returns the number of edges. Parallel edges are counted separately.
+func (f *Frozen[T]) EdgeCount() int {
+	return f.edges
+}
+
+// Index returns the index of the node with the given value.
+func (f *Frozen[T]) Index(value T) (int, bool) {
+	i, exists := f.index[value]
+	return i, exists
+}
+
+// Value returns the value of the node with index i. It panics if i is out of range.
+func (f *Frozen[T]) Value(i int) T {
+	return f.values[i]
+}
+
+// Successors returns the indices of the nodes reached by the outgoing edges of node i,
+// in ascending order and repeated for parallel edges. For undirected graphs these are all neighbors.
+// The returned slice shares memory with the graph and must not be modified.
+func (f *Frozen[T]) Successors(i int) []int {
+	return f.targets[f.offsets[i]:f.offsets[i+1]:f.offsets[i+1]]
+}
+
+// Predecessors returns the indices of the nodes with an edge to node i, in ascending order.
+// For undirected graphs it equals Successors. The returned slice must not be modified.
+func (f *Frozen[T]) Predecessors(i int) []int {
+	if !f.directed {
+		return f.Successors(i)
+	}
+	return f.inSources[f.inOffsets[i]:f.inOffsets[i+1]:f.inOffsets[i+1]]
+}
+
+// HasNode checks if a node exists in the graph.
+func (f *Frozen[T]) HasNode(value T) bool {
+	_, exists := f.index[value]
+	return exists
+}
+
+// HasEdge checks if an edge exists between two nodes.
+func (f *Frozen[T]) HasEdge(from, to T) bool {
+	lo, hi := f.arcsBetween(from, to)
+	return lo < hi
+}
+
+// GetEdgeWeight returns the weight of the edge between two nodes.
+// In a multigraph the weight of the edge with the smallest ID is returned.
+func (f *Frozen[T]) GetEdgeWeight(from, to T) (float64, bool) {
+	lo, hi := f.arcsBetween(from, to)
+	if lo == hi {
+		return 0, false
+	}
+	return f.weights[lo], true
+}
+
+// GetEdge returns the edge between two nodes, including its weight and attributes.
+// In a multigraph the edge with the smallest ID is returned.
+func (f *Frozen[T]) GetEdge(from, to T) (Edge[T], bool) {
+	lo, hi := f.arcsBetween(from, to)
+	if lo == hi {
+		return Edge[T]{}, false
+	}
+	return f.edgeView(f.index[from], lo), true
+}
+
+// EdgesBetween returns all edges from one node to another in ID order.
+func (f *Frozen[T]) EdgesBetween(from, to T) []Edge[T] {
+	lo, hi := f.arcsBetween(from, to)
+	var edges []Edge[T]
+	for a := lo; a < hi; a++ {
+		edges = append(edges, f.edgeView(f.index[from], a))
+	}
+	return edges
+}
+
+// arcsBetween returns the range of positions holding the edges from one node to another.
+func (f *Frozen[T]) arcsBetween(from, to T) (int, int) {
+	u, fromExists := f.index[from]
+	v, toExists := f.index[to]
+	if !fromExists || !toExists {
+		return 0, 0
+	}
+
+	row := f.targets[f.offsets[u]:f.offsets[u+1]]
+	lo := sort.SearchInts(row, v)
+	hi := lo
+	for hi < len(row) && row[hi] == v {
+		hi++
+	}
+	return f.offsets[u] + lo, f.offsets[u] + hi
+}
+
+// NodeAttrs returns the attributes of a node.
+// They are empty if the node does not exist or has no attributes.
+func (f *Frozen[T]) NodeAttrs(value T) Attrs {
+	i, exists := f.index[value]
+	if !exists || f.nodeAttrs == nil {
+		return Attrs{}
+	}
+	return f.nodeAttrs[i]
+}
+
+// EdgeAttrs returns the attributes of the edge between two nodes.
+// They are empty if the edge does not exist or has no attributes.
+func (f *Frozen[T]) EdgeAttrs(from, to T) Attrs {
+	lo, hi := f.arcsBetween(from, to)
+	if lo == hi {
+		return Attrs{}
+	}
+	return f.arcAttrs(lo)
+}
+
+func (f *Frozen[T]) arcAttrs(a int) Attrs {
+	if f.edgeAttrs == nil {
+		return Attrs{}
+	}
+	return f.edgeAttrs[a]
+}
+
+// edgeView returns the public description of the edge stored at position a in the row of node u.
+func (f *Frozen[T]) edgeView(u, a int) Edge[T] {
+	return Edge[T]{
+		ID:     f.ids[a],
+		From:   f.values[u],
+		To:     f.values[f.targets[a]],
+		Weight: f.weights[a],
+		Attrs:  f.arcAttrs(a),
+	}
+}
+
+// Neighbors returns a slice of nodes adjacent to the given node.
+func (f *Frozen[T]) Neighbors(value T) []T {
+	u, exists := f.index[value]
+	if !exists {
+		return nil
+	}
+
+	neighbors := make([]T, 0, f.offsets[u+1]-f.offsets[u])
+	for a := f.offsets[u]; a < f.offsets[u+1]; a++ {
+		if a == f.offsets[u] || f.targets[a] != f.targets[a-1] {
+			neighbors = append(neighbors, f.values[f.targets[a]])
+		}
+	}
+	return neighbors
+}
+
+// Nodes returns a slice of all node values in index order.
+func (f *Frozen[T]) Nodes() []T {
+	return append([]T(nil), f.values...)
+}
+
+// Edges returns a slice of all edges in the graph.
+// In a multigraph a pair of nodes appears once for every parallel edge.
+func (f *Frozen[T]) Edges() [][2]T {
+	edges := make([][2]T, 0, f.edges)
+	for u, from := range f.values {
+		for a := f.offsets[u]; a < f.offsets[u+1]; a++ {
+			if v := f.targets[a]; f.directed || u <= v {
+				edges = append(edges, [2]T{from, f.values[v]})
+			}
+		}
+	}
+	return edges
+}
+
+// ForEach applies a function to each node in index order.
+func (f *Frozen[T]) ForEach(fn func(T)) {
+	for _, value := range f.values {
+		fn(value)
+	}
+}
+
+// Traverse performs a breadth-first traversal starting from the given node.
+func (f *Frozen[T]) Traverse(start T, visit func(T)) {
+	f.BFS(
+		start, func(value T, _ int) bool {
+			visit(value)
+			return true
+		},
+	)
+}
+
+// neighbors returns the indices of the nodes reachable from u through edges accepted by the traversal.
+// Without options and parallel edges the row itself is returned.
+func (f *Frozen[T]) neighbors(tr *traversal[T], u int) []int {
+	row := f.Successors(u)
+	if tr.filter == nil && tr.less == nil && !f.multi {
+		return row
+	}
+
+	neighbors := make([]int, 0, len(row))
+	for k, v := range row {
+		if len(neighbors) > 0 && neighbors[len(neighbors)-1] == v {
+			continue // Parallel edge to a neighbor that was already kept
+		}
+		if tr.filter == nil || tr.filter(f.edgeView(u, f.offsets[u]+k)) {
+			neighbors = append(neighbors, v)
+		}
+	}
+	if tr.less != nil {
+		sort.Slice(
+			neighbors, func(i, j int) bool {
+				return tr.less(f.values[neighbors[i]], f.values[neighbors[j]])
+			},
+		)
+	}
+	return neighbors
+}
+
+// roots returns the index of start followed, when all components are requested, by every other node.
+func (f *Frozen[T]) roots(tr *traversal[T], start T) []int {
+	s, exists := f.index[start]
+	if !exists {
+		return nil
+	}
+	if !tr.all {
+		return []int{s}
+	}
+
+	rest := make([]int, 0, len(f.values))
+	for i := range f.values {
+		if i != s {
+			rest = append(rest, i)
+		}
+	}
+	if tr.less != nil {
+		sort.Slice(
+			rest, func(i, j int) bool {
+				return tr.less(f.values[rest[i]], f.values[rest[j]])
+			},
+		)
+	}
+	return append([]int{s}, rest...)
+}
+
+// BFS performs a breadth-first traversal starting from the given node.
+// The visit function receives each node with its distance in edges from the root of its component,
+// and the traversal stops early if it returns false.
+func (f *Frozen[T]) BFS(start T, visit func(value T, depth int) bool, opts ...TraversalOption[T]) {
+	tr := newTraversal(opts)
+	visited := make([]bool, len(f.values))
+	var queue, depths []int
+
+	for _, root := range f.roots(tr, start) {
+		if visited[root] {
+			continue
+		}
+		visited[root] = true
+		queue = append(queue[:0], root)
+		depths = append(depths[:0], 0)
+
+		for head := 0; head < len(queue); head++ {
+			u, depth := queue[head], depths[head]
+			if !visit(f.values[u], depth) {
+				return
+			}
+			if !tr.canDescend(depth + 1) {
+				continue
+			}
+			for _, v := range f.neighbors(tr, u) {
+				if !visited[v] {
+					visited[v] = true
+					queue = append(queue, v)
+					depths = append(depths, depth+1)
+				}
+			}
+		}
+	}
+}
+
+// DFS performs a depth-first traversal starting from the given node, visiting nodes in pre-order.
+// The visit function receives each node with its depth in the search tree,
+// and the traversal stops early if it returns false.
+func (f *Frozen[T]) DFS(start T, visit func(value T, depth int) bool, opts ...TraversalOption[T]) {
+	f.dfs(start, visit, nil, opts)
+}
+
+// DFSPostOrder performs a depth-first traversal starting from the given node,
+// visiting each node after all of its descendants.
+func (f *Frozen[T]) DFSPostOrder(start T, visit func(value T, depth int) bool, opts ...TraversalOption[T]) {
+	f.dfs(start, nil, visit, opts)
+}
+
+// frozenFrame is an entry of the explicit depth-first search stack of a Frozen graph.
+type frozenFrame struct {
+	node      int
+	depth     int
+	neighbors []int
+	next      int
+}
+
+// dfs runs an iterative depth-first search calling pre when a node is entered
+// and post when it is left. Either callback may be nil.
+func (f *Frozen[T]) dfs(start T, pre, post func(T, int) bool, opts []TraversalOption[T]) {
+	tr := newTraversal(opts)
+	visited := make([]bool, len(f.values))
+
+	enter := func(u, depth int) (frozenFrame, bool) {
+		visited[u] = true
+		if pre != nil && !pre(f.values[u], depth) {
+			return frozenFrame{}, false
+		}
+		frame := frozenFrame{node: u, depth: depth}
+		if tr.canDescend(depth + 1) {
+			frame.neighbors = f.neighbors(tr, u)
+		}
+		return frame, true
+	}
+
+	for _, root := range f.roots(tr, start) {
+		if visited[root] {
+			continue
+		}
+		frame, ok := enter(root, 0)
+		if !ok {
+			return
+		}
+		stack := []frozenFrame{frame}
+
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next < len(top.neighbors) {
+				v := top.neighbors[top.next]
+				top.next++
+				if !visited[v] {
+					frame, ok := enter(v, top.depth+1)
+					if !ok {
+						return
+					}
+					stack = append(stack, frame)
+				}
+				continue
+			}
+
+			stack = stack[:len(stack)-1]
+			if post != nil && !post(f.values[top.node], top.depth) {
+				return
+			}
+		}
+	}
+}
+
+// Iterator returns a breadth-first iterator over every node of the graph, one component after another
+// in index order.
+func (f *Frozen[T]) Iterator() iterator.Iterator[T] {
+	it := &frozenIterator[T]{f: f}
+	it.Reset()
+	return it
+}
+
+// frozenIterator visits the nodes of a Frozen graph breadth-first, component by component.
+type frozenIterator[T comparable] struct {
+	f       *Frozen[T]
+	visited []bool
+	queue   []int
+	root    int
+}
+
+// HasNext returns true if there are more nodes to visit.
+func (it *frozenIterator[T]) HasNext() bool {
+	if len(it.queue) > 0 {
+		return true
+	}
+	for ; it.root < len(it.visited); it.root++ {
+		if !it.visited[it.root] {
+			it.visited[it.root] = true
+			it.queue = append(it.queue, it.root)
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the next node in breadth-first order.
+func (it *frozenIterator[T]) Next() (T, bool) {
+	if !it.HasNext() {
+		var zero T
+		return zero, false
+	}
+
+	u := it.queue[0]
+	it.queue = it.queue[1:]
+	for _, v := range it.f.Successors(u) {
+		if !it.visited[v] {
+			it.visited[v] = true
+			it.queue = append(it.queue, v)
+		}
+	}
+	return it.f.values[u], true
+}
+
+// Reset restarts the iteration from the first node.
+func (it *frozenIterator[T]) Reset() {
+	it.visited = make([]bool, len(it.f.values))
+	it.queue = nil
+	it.root = 0
+}
@@ -0,0 +1,308 @@
+package graph
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/idsulik/go-collections/v3/internal/slices"
+)
+
+func TestFreeze(t *testing.T) {
+	label := NewAttr[string]("label")
+	g := New[string](true)
+	g.AddEdge("a", "b", 1)
+	g.AddEdgeWith("a", "c", 2, label.With("ac"))
+	g.AddEdge("c", "a", 3)
+	g.AddNodeWith("d", label.With("isolated"))
+
+	f := g.Freeze()
+	if f.NodeCount() != 4 || f.EdgeCount() != 3 {
+		t.Errorf("Expected 4 nodes and 3 edges, got %d and %d", f.NodeCount(), f.EdgeCount())
+	}
+	if !f.IsDirected() || f.IsMultigraph() {
+		t.Errorf("Expected a directed simple graph")
+	}
+
+	for _, v := range g.Nodes() {
+		i, ok := f.Index(v)
+		if !ok || f.Value(i) != v {
+			t.Errorf("Index and Value do not round trip for %v", v)
+		}
+	}
+	if _, ok := f.Index("x"); ok {
+		t.Error("Expected missing node to have no index")
+	}
+
+	a, _ := f.Index("a")
+	b, _ := f.Index("b")
+	c, _ := f.Index("c")
+	successors := append([]int(nil), f.Successors(a)...)
+	expected := []int{b, c}
+	sort.Ints(expected)
+	if !slices.Equal(successors, expected) {
+		t.Errorf("Expected successors %v, got %v", expected, successors)
+	}
+	if predecessors := f.Predecessors(a); len(predecessors) != 1 || predecessors[0] != c {
+		t.Errorf("Expected predecessors [%d], got %v", c, predecessors)
+	}
+
+	if !f.HasEdge("c", "a") || f.HasEdge("b", "a") || f.HasEdge("x", "a") {
+		t.Error("HasEdge does not match the original graph")
+	}
+	if w, ok := f.GetEdgeWeight("a", "c"); !ok || w != 2 {
+		t.Errorf("Expected weight 2, got %v (exists=%v)", w, ok)
+	}
+	if value, ok := label.Get(f.EdgeAttrs("a", "c")); !ok || value != "ac" {
+		t.Errorf("Expected edge label ac, got %v", value)
+	}
+	if value, ok := label.Get(f.NodeAttrs("d")); !ok || value != "isolated" {
+		t.Errorf("Expected node label isolated, got %v", value)
+	}
+	if _, ok := label.Get(f.EdgeAttrs("a", "b")); ok {
+		t.Error("Expected edge a-b to have no label")
+	}
+	if e, ok := f.GetEdge("c", "a"); !ok || e.Weight != 3 || e.From != "c" || e.To != "a" {
+		t.Errorf("Unexpected edge %+v", e)
+	}
+	if len(f.Neighbors("a")) != 2 || f.Neighbors("x") != nil {
+		t.Errorf("Unexpected neighbors %v", f.Neighbors("a"))
+	}
+	if len(f.Edges()) != 3 {
+		t.Errorf("Expected 3 edges, got %v", f.Edges())
+	}
+
+	// The frozen graph does not follow later changes
+	g.AddEdge("b", "a", 1)
+	if f.HasEdge("b", "a") {
+		t.Error("Frozen graph should be independent of the original")
+	}
+}
+
+func TestFreeze_Multigraph(t *testing.T) {
+	g := NewMultigraph[int](false)
+	first, _ := g.AddEdgeWithID(1, 2, 5)
+	second, _ := g.AddEdgeWithID(2, 1, 3)
+	g.AddEdge(2, 2, 1)
+	g.AddEdge(2, 3, 1)
+
+	f := g.Freeze()
+	if f.EdgeCount() != 4 {
+		t.Errorf("Expected 4 edges, got %d", f.EdgeCount())
+	}
+	edges := f.EdgesBetween(1, 2)
+	if len(edges) != 2 || edges[0].ID != first || edges[1].ID != second {
+		t.Errorf("Expected edges %d and %d in ID order, got %+v", first, second, edges)
+	}
+	if w, _ := f.GetEdgeWeight(2, 1); w != 5 {
+		t.Errorf("Expected the weight of the first edge, got %v", w)
+	}
+	if neighbors := f.Neighbors(2); len(neighbors) != 3 {
+		t.Errorf("Expected parallel edges to be reported once, got %v", neighbors)
+	}
+	if centrality := f.DegreeCentrality(); centrality[2] != 5.0/2 {
+		t.Errorf("Expected degree 5 for node 2, got %v", centrality[2]*2)
+	}
+}
+
+func TestFrozen_Thaw(t *testing.T) {
+	label := NewAttr[string]("label")
+	for _, directed := range []bool{false, true} {
+		g := NewMultigraph[int](directed)
+		g.AddEdge(1, 2, 1)
+		g.AddEdge(1, 2, 2)
+		g.AddEdge(3, 3, 4)
+		removed, _ := g.AddEdgeWithID(2, 4, 1)
+		g.RemoveEdgeByID(removed)
+		g.SetNodeAttrs(4, label.With("four"))
+		g.SetEdgeAttrs(3, 3, label.With("loop"))
+
+		thawed := g.Freeze().Thaw()
+		assertSameGraph(t, g, thawed)
+		if !thawed.IsMultigraph() {
+			t.Errorf("directed=%v: expected a multigraph", directed)
+		}
+		if value, _ := label.Get(thawed.NodeAttrs(4)); value != "four" {
+			t.Errorf("directed=%v: expected node label to survive, got %v", directed, value)
+		}
+		for _, e := range g.EdgesBetween(1, 2) {
+			if got, ok := thawed.EdgeByID(e.ID); !ok || got.Weight != e.Weight {
+				t.Errorf("directed=%v: expected edge %d with weight %v, got %+v", directed, e.ID, e.Weight, got)
+			}
+		}
+		if value, _ := label.Get(thawed.EdgeAttrs(3, 3)); value != "loop" {
+			t.Errorf("directed=%v: expected edge label to survive, got %v", directed, value)
+		}
+
+		id, _ := thawed.AddEdgeWithID(4, 1, 1)
+		if id <= removed {
+			t.Errorf("directed=%v: expected a fresh edge ID, got %d", directed, id)
+		}
+	}
+}
+
+func TestGraph_FrozenCache(t *testing.T) {
+	label := NewAttr[string]("label")
+	g := New[int](false)
+	g.AddEdge(1, 2, 1)
+	g.AddNode(3)
+
+	f := g.frozen()
+	if g.frozen() != f {
+		t.Error("Expected the snapshot to be reused while the graph is unchanged")
+	}
+	if f.edgeAttrs != nil || f.nodeAttrs != nil {
+		t.Error("Expected no attribute storage for a graph without attributes")
+	}
+	if len(g.ConnectedComponents()) != 2 {
+		t.Errorf("Expected 2 components, got %v", g.ConnectedComponents())
+	}
+
+	mutations := []struct {
+		name   string
+		mutate func()
+	}{
+		{"AddEdge", func() { g.AddEdge(2, 3, 1) }},
+		{"SetEdgeAttrs", func() { g.SetEdgeAttrs(2, 3, label.With("x")) }},
+		{"SetNodeAttrs", func() { g.SetNodeAttrs(1, label.With("y")) }},
+		{"RemoveEdge", func() { g.RemoveEdge(2, 3) }},
+		{"AddNode", func() { g.AddNode(4) }},
+		{"RemoveNode", func() { g.RemoveNode(4) }},
+		{"AddSelfLoop", func() { g.AddEdge(3, 3, 1) }},
+		{"RemoveSelfLoops", func() { g.RemoveSelfLoops() }},
+	}
+	for _, m := range mutations {
+		before := g.frozen()
+		m.mutate()
+		if g.frozen() == before {
+			t.Errorf("%s: expected the snapshot to be rebuilt", m.name)
+		}
+	}
+	if before := g.frozen(); g.RemoveSelfLoops() != 0 || g.frozen() != before {
+		t.Error("Expected the snapshot to be kept when no self-loop was removed")
+	}
+	if len(g.ConnectedComponents()) != 2 {
+		t.Errorf("Expected 2 components after the changes, got %v", g.ConnectedComponents())
+	}
+	if _, ok := label.Get(g.frozen().NodeAttrs(1)); !ok {
+		t.Error("Expected the snapshot to have the new node attributes")
+	}
+}
+
+func TestFrozen_Traversal(t *testing.T) {
+	g := treeGraph()
+	f := g.Freeze()
+
+	optionSets := [][]TraversalOption[int]{
+		nil,
+		{WithAllComponents[int]()},
+		{WithMaxDepth[int](1)},
+		{WithEdgeFilter(func(e Edge[int]) bool { return e.Weight < 7 })},
+	}
+	for i, opts := range optionSets {
+		opts = append(opts, WithNodeOrder(lessInt))
+		for _, run := range []struct {
+			name          string
+			graph, frozen func(int, func(int, int) bool, ...TraversalOption[int])
+		}{
+			{"BFS", g.BFS, f.BFS},
+			{"DFS", g.DFS, f.DFS},
+			{"DFSPostOrder", g.DFSPostOrder, f.DFSPostOrder},
+		} {
+			expected, expectedDepths := collect(run.graph, 1, opts...)
+			got, depths := collect(run.frozen, 1, opts...)
+			if !slices.Equal(got, expected) || !slices.Equal(depths, expectedDepths) {
+				t.Errorf(
+					"%s with options %d: expected %v %v, got %v %v",
+					run.name, i, expected, expectedDepths, got, depths,
+				)
+			}
+		}
+	}
+
+	var visited []int
+	f.Traverse(7, func(v int) { visited = append(visited, v) })
+	if len(visited) != 2 {
+		t.Errorf("Expected to visit 7 and 8, got %v", visited)
+	}
+
+	count := 0
+	for it := f.Iterator(); it.HasNext(); {
+		if _, ok := it.Next(); ok {
+			count++
+		}
+	}
+	if count != 8 {
+		t.Errorf("Expected iterator to cover all 8 nodes, got %d", count)
+	}
+}
+
+func TestFrozen_Algorithms(t *testing.T) {
+	g := bowtieGraph(false)
+	f := g.Freeze()
+
+	if components := f.ConnectedComponents(); len(components) != 3 {
+		t.Errorf("Expected 3 components, got %v", components)
+	}
+	if bridges := f.Bridges(); len(bridges) != 2 {
+		t.Errorf("Expected 2 bridges, got %v", bridges)
+	}
+	points := f.ArticulationPoints()
+	sort.Ints(points)
+	if !slices.Equal(points, []int{3, 5}) {
+		t.Errorf("Expected articulation points [3 5], got %v", points)
+	}
+	if components := f.BiconnectedComponents(); len(components) != 4 {
+		t.Errorf("Expected 4 biconnected components, got %v", components)
+	}
+
+	expected, _ := g.BetweennessCentrality(true)
+	got, err := f.BetweennessCentrality(true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertScores(t, expected, got)
+
+	ranks, err := f.PageRank()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sum := 0.0
+	for _, r := range ranks {
+		sum += r
+	}
+	if math.Abs(sum-1) > 1e-6 {
+		t.Errorf("Ranks should sum to 1, got %v", sum)
+	}
+
+	flow, err := clrsNetwork().Freeze().Dinic("s", "t")
+	if err != nil || flow.Value != 23 {
+		t.Errorf("Expected max flow 23, got %v (%v)", flow, err)
+	}
+}
+
+func BenchmarkTraversal(b *testing.B) {
+	g := New[int](true)
+	const n = 100000
+	for i := 0; i < n; i++ {
+		g.AddEdge(i, (i*7+1)%n, 1)
+		g.AddEdge(i, (i*13+5)%n, 1)
+	}
+	visit := func(int, int) bool { return true }
+
+	b.Run(
+		"Graph", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				g.BFS(0, visit)
+			}
+		},
+	)
+	f := g.Freeze()
+	b.Run(
+		"Frozen", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				f.BFS(0, visit)
+			}
+		},
+	)
+}
@@ -0,0 +1,78 @@
+package generate
+
+import "github.com/idsulik/go-collections/v3/graph"
+
+// Complete returns the complete graph on n nodes, in which every pair of distinct nodes is connected.
+func Complete(n int, opts ...Option) *graph.Graph[int] {
+	return newConfig(opts).complete(n)
+}
+
+// complete returns the complete graph on n nodes built with the configuration c.
+func (c *config) complete(n int) *graph.Graph[int] {
+	g := c.newGraph(n)
+	for u := 0; u < n; u++ {
+		for v := 0; v < n; v++ {
+			if u != v && (c.directed || u < v) {
+				c.addEdge(g, u, v)
+			}
+		}
+	}
+	return g
+}
+
+// Star returns a star on n nodes: node 0 is connected to every other node.
+// Directed stars point outwards from the center.
+func Star(n int, opts ...Option) *graph.Graph[int] {
+	c := newConfig(opts)
+	g := c.newGraph(n)
+	for v := 1; v < n; v++ {
+		c.addEdge(g, 0, v)
+	}
+	return g
+}
+
+// Path returns the path 0 - 1 - ... - n-1.
+func Path(n int, opts ...Option) *graph.Graph[int] {
+	c := newConfig(opts)
+	g := c.newGraph(n)
+	for v := 1; v < n; v++ {
+		c.addEdge(g, v-1, v)
+	}
+	return g
+}
+
+// Cycle returns the cycle 0 - 1 - ... - n-1 - 0. A cycle on a single node is a self-loop,
+// and on two nodes of an undirected graph a single edge.
+func Cycle(n int, opts ...Option) *graph.Graph[int] {
+	c := newConfig(opts)
+	g := c.newGraph(n)
+	for v := 1; v < n; v++ {
+		c.addEdge(g, v-1, v)
+	}
+	if n > 0 {
+		c.addEdge(g, n-1, 0)
+	}
+	return g
+}
+
+// Grid returns a rows x cols lattice in which node r*cols+c is connected to its right and lower neighbors.
+// Directed grids point right and down.
+func Grid(rows, cols int, opts ...Option) *graph.Graph[int] {
+	if rows < 0 || cols < 0 {
+		rows, cols = 0, 0
+	}
+	c := newConfig(opts)
+	g := c.newGraph(rows * cols)
+	for r := 0; r < rows; r++ {
+		for col := 0; col < cols; col++ {
+			v := r*cols + col
+			if col+1 < cols {
+				c.addEdge(g, v, v+1)
+			}
+			if r+1 < rows {
+				c.addEdge(g, v, v+cols)
+			}
+		}
+	}
+	return g
+}
@@ -0,0 +1,86 @@
+// Package generate builds classic and random graphs for tests and benchmarks.
+// Nodes are the integers 0 to n-1.
+package generate
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/idsulik/go-collections/v3/graph"
+)
+
+// ErrInvalidParameter is returned when a generator is given parameters outside their valid range.
+var ErrInvalidParameter = errors.New("generate: invalid parameter")
+
+// Option configures a generator.
+type Option func(*config)
+
+type config struct {
+	directed  bool
+	rng       *rand.Rand
+	weighted  bool
+	minWeight float64
+	maxWeight float64
+}
+
+// WithDirected makes the generator produce a directed graph.
+// It is ignored by generators whose model defines the direction of the edges.
+func WithDirected() Option {
+	return func(c *config) {
+		c.directed = true
+	}
+}
+
+// WithSeed makes the generator deterministic by seeding its random source.
+func WithSeed(seed int64) Option {
+	return func(c *config) {
+		c.rng = rand.New(rand.NewSource(seed))
+	}
+}
+
+// WithRand makes the generator draw random numbers from rng.
+func WithRand(rng *rand.Rand) Option {
+	return func(c *config) {
+		c.rng = rng
+	}
+}
+
+// WithWeights assigns every edge a weight drawn uniformly from [minWeight, maxWeight).
+// Without it all edges have weight 1.
+func WithWeights(minWeight, maxWeight float64) Option {
+	return func(c *config) {
+		c.weighted = true
+		c.minWeight = minWeight
+		c.maxWeight = maxWeight
+	}
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.rng == nil {
+		c.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return c
+}
+
+// newGraph returns a graph with the nodes 0 to n-1.
+func (c *config) newGraph(n int) *graph.Graph[int] {
+	g := graph.New[int](c.directed)
+	for i := 0; i < n; i++ {
+		g.AddNode(i)
+	}
+	return g
+}
+
+// addEdge adds an edge with weight 1 or a random weight.
+func (c *config) addEdge(g *graph.Graph[int], from, to int) {
+	weight := 1.0
+	if c.weighted {
+		weight = c.minWeight + c.rng.Float64()*(c.maxWeight-c.minWeight)
+	}
+	g.AddEdge(from, to, weight)
+}
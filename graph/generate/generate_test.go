@@ -0,0 +1,246 @@
+package generate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/idsulik/go-collections/v3/graph"
+)
+
+func TestClassic(t *testing.T) {
+	tests := []struct {
+		name  string
+		g     *graph.Graph[int]
+		nodes int
+		edges int
+	}{
+		{"complete", Complete(5), 5, 10},
+		{"complete directed", Complete(5, WithDirected()), 5, 20},
+		{"star", Star(5), 5, 4},
+		{"path", Path(5), 5, 4},
+		{"cycle", Cycle(5), 5, 5},
+		{"cycle of one", Cycle(1), 1, 1},
+		{"grid", Grid(3, 4), 12, 17},
+		{"empty", Complete(0), 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				if nodes := len(tt.g.Nodes()); nodes != tt.nodes {
+					t.Errorf("Expected %d nodes, got %d", tt.nodes, nodes)
+				}
+				if edges := len(tt.g.Edges()); edges != tt.edges {
+					t.Errorf("Expected %d edges, got %d", tt.edges, edges)
+				}
+			},
+		)
+	}
+
+	if g := Grid(2, 3, WithDirected()); !g.HasEdge(1, 4) || g.HasEdge(4, 1) || !g.HasEdge(4, 5) {
+		t.Errorf("Unexpected directed grid %v", g.Edges())
+	}
+	if g := Star(4, WithDirected()); len(g.Neighbors(0)) != 3 || len(g.Neighbors(1)) != 0 {
+		t.Errorf("Expected directed star to point outwards, got %v", g.Edges())
+	}
+}
+
+func TestWeights(t *testing.T) {
+	g := Complete(10, WithWeights(2, 3), WithSeed(1))
+	for _, e := range g.Edges() {
+		w, _ := g.GetEdgeWeight(e[0], e[1])
+		if w < 2 || w >= 3 {
+			t.Errorf("Weight %v of edge %v outside [2, 3)", w, e)
+		}
+	}
+
+	for _, e := range Path(5).Edges() {
+		if w, _ := Path(5).GetEdgeWeight(e[0], e[1]); w != 1 {
+			t.Errorf("Expected default weight 1, got %v", w)
+		}
+	}
+}
+
+func TestErdosRenyi(t *testing.T) {
+	const n, p = 400, 0.05
+	for _, directed := range []bool{false, true} {
+		opts := []Option{WithSeed(7)}
+		pairs := float64(n * (n - 1) / 2)
+		if directed {
+			opts = append(opts, WithDirected())
+			pairs *= 2
+		}
+
+		g, err := ErdosRenyi(n, p, opts...)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		// The edge count is binomial; allow five standard deviations
+		expected := pairs * p
+		if edges := float64(len(g.Edges())); math.Abs(edges-expected) > 5*math.Sqrt(expected) {
+			t.Errorf("directed=%v: expected about %.0f edges, got %.0f", directed, expected, edges)
+		}
+		for _, e := range g.Edges() {
+			if e[0] == e[1] {
+				t.Errorf("directed=%v: unexpected self-loop %v", directed, e)
+			}
+		}
+	}
+
+	if g, _ := ErdosRenyi(5, 1); len(g.Edges()) != 10 {
+		t.Errorf("Expected complete graph for p=1, got %v", g.Edges())
+	}
+	if g, _ := ErdosRenyi(5, 0); len(g.Edges()) != 0 || len(g.Nodes()) != 5 {
+		t.Errorf("Expected empty graph for p=0, got %v", g.Edges())
+	}
+	if g, _ := ErdosRenyi(5, 1, WithDirected()); len(g.Edges()) != 20 {
+		t.Errorf("Expected complete directed graph for p=1, got %v", g.Edges())
+	}
+	if _, err := ErdosRenyi(5, 1.5); err != ErrInvalidParameter {
+		t.Errorf("Expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestSkip(t *testing.T) {
+	c := newConfig([]Option{WithSeed(1)})
+	tests := []struct {
+		name string
+		k    int
+		p    float64
+	}{
+		{"gap beyond int32", -1, 1e-12},
+		{"probability below epsilon", -1, 1e-300},
+		{"large start", math.MaxInt - 10, 1e-12},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				for i := 0; i < 100; i++ {
+					if next := c.skip(tt.k, tt.p); next <= tt.k {
+						t.Fatalf("skip(%d, %g) = %d, want a later index", tt.k, tt.p, next)
+					}
+				}
+			},
+		)
+	}
+
+	// Gaps are no longer capped at math.MaxInt32
+	beyond := 0
+	for i := 0; i < 100; i++ {
+		if next := c.skip(-1, 1e-12); next > math.MaxInt32 && next < math.MaxInt {
+			beyond++
+		}
+	}
+	if beyond == 0 {
+		t.Error("Expected gaps beyond math.MaxInt32 for small probabilities")
+	}
+}
+
+func TestSeed(t *testing.T) {
+	a, _ := BarabasiAlbert(200, 3, WithSeed(42), WithWeights(0, 1))
+	b, _ := BarabasiAlbert(200, 3, WithSeed(42), WithWeights(0, 1))
+	for _, e := range a.Edges() {
+		wa, _ := a.GetEdgeWeight(e[0], e[1])
+		wb, ok := b.GetEdgeWeight(e[0], e[1])
+		if !ok || wa != wb {
+			t.Fatalf("Expected identical graphs for the same seed, edge %v differs", e)
+		}
+	}
+}
+
+func TestRandomDAG(t *testing.T) {
+	g, err := RandomDAG(100, 0.1, WithSeed(3))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(g.Edges()) == 0 {
+		t.Error("Expected some edges")
+	}
+	for _, e := range g.Edges() {
+		if e[0] >= e[1] {
+			t.Errorf("Edge %v does not follow the topological order", e)
+		}
+	}
+
+	if g, _ := RandomDAG(4, 1); len(g.Edges()) != 6 || !g.HasEdge(0, 3) || g.HasEdge(3, 0) {
+		t.Errorf("Expected transitive tournament for p=1, got %v", g.Edges())
+	}
+
+	// The caller's options must not be modified
+	opts := make([]Option, 1, 2)
+	opts[0] = WithSeed(3)
+	spare := append(opts, WithWeights(5, 5))
+	if _, err := RandomDAG(4, 0.5, opts...); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if w, _ := Complete(2, spare...).GetEdgeWeight(0, 1); w != 5 {
+		t.Errorf("Expected the spare options to keep their weights, got %v", w)
+	}
+}
+
+func TestBarabasiAlbert(t *testing.T) {
+	const n, m = 500, 2
+	g, err := BarabasiAlbert(n, m, WithSeed(5), WithDirected())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if edges := len(g.Edges()); edges != m+(n-m-1)*m {
+		t.Errorf("Expected %d edges, got %d", m+(n-m-1)*m, edges)
+	}
+	if !g.HasEdge(1, 0) {
+		t.Error("Expected an undirected graph")
+	}
+
+	// Preferential attachment produces hubs far above the average degree
+	maxDegree := 0
+	for _, v := range g.Nodes() {
+		if d := len(g.Neighbors(v)); d > maxDegree {
+			maxDegree = d
+		}
+	}
+	if maxDegree < 5*2*m {
+		t.Errorf("Expected a hub, the highest degree is %d", maxDegree)
+	}
+
+	if _, err := BarabasiAlbert(3, 3); err != ErrInvalidParameter {
+		t.Errorf("Expected ErrInvalidParameter, got %v", err)
+	}
+}
+
+func TestWattsStrogatz(t *testing.T) {
+	const n, k = 100, 4
+	lattice, err := WattsStrogatz(n, k, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, v := range lattice.Nodes() {
+		if d := len(lattice.Neighbors(v)); d != k {
+			t.Fatalf("Expected every node of the ring lattice to have degree %d, got %d", k, d)
+		}
+	}
+
+	g, err := WattsStrogatz(n, k, 0.3, WithSeed(11))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if edges := len(g.Edges()); edges != n*k/2 {
+		t.Errorf("Rewiring should keep %d edges, got %d", n*k/2, edges)
+	}
+	rewired := 0
+	for _, e := range g.Edges() {
+		if !lattice.HasEdge(e[0], e[1]) {
+			rewired++
+		}
+		if e[0] == e[1] {
+			t.Errorf("Unexpected self-loop %v", e)
+		}
+	}
+	if rewired == 0 {
+		t.Error("Expected some edges to be rewired")
+	}
+
+	if _, err := WattsStrogatz(4, 4, 0.1); err != ErrInvalidParameter {
+		t.Errorf("Expected ErrInvalidParameter, got %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package generate
+
+import (
+	"math"
+
+	"github.com/idsulik/go-collections/v3/graph"
+)
+
+// ErdosRenyi returns a G(n, p) random graph in which every pair of distinct nodes is connected
+// independently with probability p. It runs in time proportional to the number of nodes and edges.
+func ErdosRenyi(n int, p float64, opts ...Option) (*graph.Graph[int], error) {
+	if n < 0 || p < 0 || p > 1 {
+		return nil, ErrInvalidParameter
+	}
+	c := newConfig(opts)
+	if p == 1 {
+		return c.complete(n), nil
+	}
+
+	g := c.newGraph(n)
+	if p == 0 {
+		return g, nil
+	}
+	if c.directed {
+		// Skip over the n(n-1) ordered pairs with geometrically distributed gaps
+		pairs := n * (n - 1)
+		for k := c.skip(-1, p); k < pairs; k = c.skip(k, p) {
+			u, v := k/(n-1), k%(n-1)
+			if v >= u {
+				v++
+			}
+			c.addEdge(g, u, v)
+		}
+		return g, nil
+	}
+	c.lowerPairs(n, p, func(u, v int) { c.addEdge(g, u, v) })
+	return g, nil
+}
+
+// RandomDAG returns a random directed acyclic graph in which every pair u < v is connected by
+// an edge u->v independently with probability p, so 0, 1, ..., n-1 is a topological order.
+func RandomDAG(n int, p float64, opts ...Option) (*graph.Graph[int], error) {
+	if n < 0 || p < 0 || p > 1 {
+		return nil, ErrInvalidParameter
+	}
+	c := newConfig(opts)
+	c.directed = true
+	g := c.newGraph(n)
+	if p == 0 {
+		return g, nil
+	}
+	if p == 1 {
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				c.addEdge(g, u, v)
+			}
+		}
+		return g, nil
+	}
+	c.lowerPairs(n, p, func(u, v int) { c.addEdge(g, v, u) })
+	return g, nil
+}
+
+// skip returns the index of the next selected item after k when every item is selected with probability p.
+// It returns math.MaxInt if the next item is beyond the range of int.
+func (c *config) skip(k int, p float64) int {
+	// Log1p keeps the gap finite for probabilities too small to change 1-p
+	gap := math.Floor(math.Log1p(-c.rng.Float64()) / math.Log1p(-p))
+	if gap >= float64(math.MaxInt-k-1) {
+		return math.MaxInt
+	}
+	return k + 1 + int(gap)
+}
+
+// lowerPairs calls add for each pair u > v selected with probability p (Batagelj and Brandes).
+func (c *config) lowerPairs(n int, p float64, add func(u, v int)) {
+	u, v := 1, -1
+	for u < n {
+		v = c.skip(v, p)
+		for v >= u && u < n {
+			v -= u
+			u++
+		}
+		if u < n {
+			add(u, v)
+		}
+	}
+}
+
+// BarabasiAlbert returns an undirected scale-free graph grown by preferential attachment.
+// It starts from a star on m+1 nodes and connects every further node to m distinct existing
+// nodes chosen with probability proportional to their degree. It requires 1 <= m < n.
+func BarabasiAlbert(n, m int, opts ...Option) (*graph.Graph[int], error) {
+	if m < 1 || m >= n {
+		return nil, ErrInvalidParameter
+	}
+	c := newConfig(opts)
+	c.directed = false
+	g := c.newGraph(n)
+
+	// Every node appears in repeated once per incident edge
+	repeated := make([]int, 0, 2*m*n)
+	for v := 1; v <= m; v++ {
+		c.addEdge(g, 0, v)
+		repeated = append(repeated, 0, v)
+	}
+
+	targets := make([]int, 0, m)
+	chosen := make(map[int]bool, m)
+	for v := m + 1; v < n; v++ {
+		targets = targets[:0]
+		for u := range chosen {
+			delete(chosen, u)
+		}
+		for len(targets) < m {
+			u := repeated[c.rng.Intn(len(repeated))]
+			if !chosen[u] {
+				chosen[u] = true
+				targets = append(targets, u)
+			}
+		}
+		for _, u := range targets {
+			c.addEdge(g, v, u)
+			repeated = append(repeated, u, v)
+		}
+	}
+	return g, nil
+}
+
+// WattsStrogatz returns an undirected small-world graph. It starts from a ring in which every node
+// is connected to its k/2 nearest neighbors on each side, then rewires each edge to a random
+// node with probability beta, avoiding self-loops and duplicate edges. It requires 0 <= k < n.
+func WattsStrogatz(n, k int, beta float64, opts ...Option) (*graph.Graph[int], error) {
+	if n < 0 || k < 0 || (k >= n && n > 0) || beta < 0 || beta > 1 {
+		return nil, ErrInvalidParameter
+	}
+	c := newConfig(opts)
+	c.directed = false
+	g := c.newGraph(n)
+
+	for j := 1; j <= k/2; j++ {
+		for u := 0; u < n; u++ {
+			c.addEdge(g, u, (u+j)%n)
+		}
+	}
+
+	for j := 1; j <= k/2; j++ {
+		for u := 0; u < n; u++ {
+			v := (u + j) % n
+			weight, exists := g.GetEdgeWeight(u, v)
+			if !exists || c.rng.Float64() >= beta || len(g.Neighbors(u)) >= n-1 {
+				continue
+			}
+			w := c.rng.Intn(n)
+			for w == u || g.HasEdge(u, w) {
+				w = c.rng.Intn(n)
+			}
+			g.RemoveEdge(u, v)
+			g.AddEdge(u, w, weight)
+		}
+	}
+	return g, nil
+}
@@ -7,21 +7,69 @@ import (
 // Graph represents the graph data structure.
 type Graph[T comparable] struct {
 	directed bool
+	multi    bool
 	nodes    map[T]*node[T]
+	edges    map[int]*edge[T] // Edges by ID, in their from->to direction
+	nextID   int
+	cache    *frozenCache[T]
 }
 
 // node represents a node in the graph.
 type node[T comparable] struct {
 	value    T
+	attrs    Attrs
 	edges    map[T]*edge[T]
 	incoming map[T]*edge[T] // For directed graphs
 }
 
 // edge represents an edge between two nodes.
+// Parallel edges between the same pair of nodes are chained through next.
 type edge[T comparable] struct {
+	id     int
 	from   *node[T]
 	to     *node[T]
 	weight float64
+	attrs  Attrs
+	next   *edge[T]
+}
+
+// Edge describes an edge together with its weight and attributes.
+type Edge[T comparable] struct {
+	ID     int
+	From   T
+	To     T
+	Weight float64
+	Attrs  Attrs
+}
+
+// WeightFunc extracts the weight of an edge for weighted algorithms.
+type WeightFunc[T comparable] func(e Edge[T]) float64
+
+// EdgeWeight is the default WeightFunc. It returns the weight the edge was added with.
+func EdgeWeight[T comparable](e Edge[T]) float64 {
+	return e.Weight
+}
+
+// WeightFrom returns a WeightFunc that computes the weight from an edge attribute.
+// Edges without the attribute fall back to their stored weight.
+func WeightFrom[T comparable, V any](attr Attr[V], fn func(V) float64) WeightFunc[T] {
+	return func(e Edge[T]) float64 {
+		if value, ok := attr.Get(e.Attrs); ok {
+			return fn(value)
+		}
+		return e.Weight
+	}
+}
+
+// view returns the public description of the edge.
+func (e *edge[T]) view() Edge[T] {
+	return Edge[T]{
+		ID:     e.id,
+		From:   e.from.value,
+		To:     e.to.value,
+		Weight: e.weight,
+		Attrs:  e.attrs,
+	}
 }
 
 // New creates a new Graph. If directed is true, the graph is directed.
@@ -29,17 +77,27 @@ func New[T comparable](directed bool) *Graph[T] {
 	return &Graph[T]{
 		directed: directed,
 		nodes:    make(map[T]*node[T]),
+		edges:    make(map[int]*edge[T]),
+		cache:    &frozenCache[T]{},
 	}
 }
 
 // AddNode adds a node to the graph and returns true if added, false if it already exists.
 func (g *Graph[T]) AddNode(value T) bool {
+	return g.AddNodeWith(value)
+}
+
+// AddNodeWith adds a node with the given attributes and returns true if added.
+// If the node already exists it is left unchanged and false is returned.
+func (g *Graph[T]) AddNodeWith(value T, attrs ...AttrValue) bool {
 	if _, exists := g.nodes[value]; exists {
 		return false
 	}
 
+	g.changed()
 	g.nodes[value] = &node[T]{
 		value:    value,
+		attrs:    Attrs{}.with(attrs),
 		edges:    make(map[T]*edge[T]),
 		incoming: make(map[T]*edge[T]),
 	}
@@ -47,53 +105,75 @@ func (g *Graph[T]) AddNode(value T) bool {
 }
 
 // AddEdge adds an edge between two nodes with an optional weight and returns true if added, false if it already exists.
+// In a multigraph a new parallel edge is always added.
 func (g *Graph[T]) AddEdge(from, to T, weight float64) bool {
-	fromNode, fromExists := g.nodes[from]
-	toNode, toExists := g.nodes[to]
+	_, added := g.AddEdgeWithID(from, to, weight)
+	return added
+}
 
-	if !fromExists {
-		fromNode = &node[T]{
-			value:    from,
-			edges:    make(map[T]*edge[T]),
-			incoming: make(map[T]*edge[T]),
-		}
-		g.nodes[from] = fromNode
-	}
+// AddEdgeWithID adds an edge like AddEdge and also returns the ID assigned to it.
+// The ID can be used with EdgeByID and RemoveEdgeByID.
+func (g *Graph[T]) AddEdgeWithID(from, to T, weight float64) (int, bool) {
+	return g.AddEdgeWith(from, to, weight)
+}
 
-	if !toExists {
-		toNode = &node[T]{
-			value:    to,
-			edges:    make(map[T]*edge[T]),
-			incoming: make(map[T]*edge[T]),
-		}
-		g.nodes[to] = toNode
-	}
+// AddEdgeWith adds an edge with the given attributes like AddEdgeWithID, and returns its ID.
+// If the edge already exists in a simple graph, it is left unchanged and false is returned.
+func (g *Graph[T]) AddEdgeWith(from, to T, weight float64, attrs ...AttrValue) (int, bool) {
+	g.AddNode(from)
+	g.AddNode(to)
+	fromNode, toNode := g.nodes[from], g.nodes[to]
 
-	if _, exists := fromNode.edges[to]; exists {
-		return false // Edge already exists
+	if _, exists := fromNode.edges[to]; exists && !g.multi {
+		return 0, false // Edge already exists
 	}
 
+	g.changed()
+	g.nextID++
 	newEdge := &edge[T]{
+		id:     g.nextID,
 		from:   fromNode,
 		to:     toNode,
 		weight: weight,
+		attrs:  Attrs{}.with(attrs),
 	}
 
-	fromNode.edges[to] = newEdge
+	appendEdge(fromNode.edges, to, newEdge)
+	g.edges[newEdge.id] = newEdge
 
 	if !g.directed {
-		// For undirected graphs, add the edge in both directions
-		toNode.edges[from] = &edge[T]{
-			from:   toNode,
-			to:     fromNode,
-			weight: weight,
+		// For undirected graphs, add the edge in both directions.
+		// A self-loop is stored only once.
+		if fromNode != toNode {
+			appendEdge(
+				toNode.edges, from, &edge[T]{
+					id:     newEdge.id,
+					from:   toNode,
+					to:     fromNode,
+					weight: weight,
+					attrs:  newEdge.attrs,
+				},
+			)
 		}
 	} else {
-		// For directed graphs, update incoming edges
-		toNode.incoming[from] = newEdge
+		// For directed graphs, incoming edges share the chain of outgoing edges
+		toNode.incoming[from] = fromNode.edges[to]
 	}
 
-	return true
+	return newEdge.id, true
+}
+
+// appendEdge adds e to the end of the chain of edges stored under key.
+func appendEdge[T comparable](edges map[T]*edge[T], key T, e *edge[T]) {
+	head, exists := edges[key]
+	if !exists {
+		edges[key] = e
+		return
+	}
+	for head.next != nil {
+		head = head.next
+	}
+	head.next = e
 }
 
 // RemoveNode removes a node and all connected edges, returns true if removed, false if not found.
@@ -102,6 +182,16 @@ func (g *Graph[T]) RemoveNode(value T) bool {
 	if !exists {
 		return false
 	}
+	g.changed()
+
+	// Forget the IDs of all edges touching this node
+	for _, chains := range []map[T]*edge[T]{nodeToRemove.edges, nodeToRemove.incoming} {
+		for _, head := range chains {
+			for e := head; e != nil; e = e.next {
+				delete(g.edges, e.id)
+			}
+		}
+	}
 
 	// Remove all edges from other nodes to this node
 	for _, n := range g.nodes {
@@ -121,6 +211,7 @@ func (g *Graph[T]) RemoveNode(value T) bool {
 }
 
 // RemoveEdge removes an edge between two nodes and returns true if removed, false if not found.
+// In a multigraph all parallel edges between the two nodes are removed.
 func (g *Graph[T]) RemoveEdge(from, to T) bool {
 	fromNode, fromExists := g.nodes[from]
 	toNode, toExists := g.nodes[to]
@@ -129,10 +220,15 @@ func (g *Graph[T]) RemoveEdge(from, to T) bool {
 		return false
 	}
 
-	if _, exists := fromNode.edges[to]; !exists {
+	head, exists := fromNode.edges[to]
+	if !exists {
 		return false // Edge does not exist
 	}
 
+	g.changed()
+	for e := head; e != nil; e = e.next {
+		delete(g.edges, e.id)
+	}
 	delete(fromNode.edges, to)
 
 	if !g.directed {
@@ -186,7 +282,79 @@ func (g *Graph[T]) GetEdgeWeight(from, to T) (float64, bool) {
 	return 0, false
 }
 
+// NodeAttrs returns the attributes of a node.
+// They are empty if the node does not exist or has no attributes.
+func (g *Graph[T]) NodeAttrs(value T) Attrs {
+	if node, exists := g.nodes[value]; exists {
+		return node.attrs
+	}
+	return Attrs{}
+}
+
+// SetNodeAttrs sets attributes of a node, keeping its other attributes, and returns true if the node exists.
+func (g *Graph[T]) SetNodeAttrs(value T, attrs ...AttrValue) bool {
+	node, exists := g.nodes[value]
+	if !exists {
+		return false
+	}
+	g.changed()
+	node.attrs = node.attrs.with(attrs)
+	return true
+}
+
+// EdgeAttrs returns the attributes of the edge between two nodes.
+// They are empty if the edge does not exist or has no attributes.
+func (g *Graph[T]) EdgeAttrs(from, to T) Attrs {
+	if fromNode, fromExists := g.nodes[from]; fromExists {
+		if edge, exists := fromNode.edges[to]; exists {
+			return edge.attrs
+		}
+	}
+	return Attrs{}
+}
+
+// SetEdgeAttrs sets attributes of the edge between two nodes, keeping its other attributes,
+// and returns true if the edge exists. For undirected graphs both directions share the attributes.
+// In a multigraph the first edge between the nodes is updated; use SetEdgeAttrsByID for the others.
+func (g *Graph[T]) SetEdgeAttrs(from, to T, attrs ...AttrValue) bool {
+	fromNode, fromExists := g.nodes[from]
+	if !fromExists {
+		return false
+	}
+	edge, exists := fromNode.edges[to]
+	if !exists {
+		return false
+	}
+
+	g.setEdgeAttrs(edge, edge.attrs.with(attrs))
+	return true
+}
+
+// setEdgeAttrs replaces the attributes of e and, for undirected graphs, of its reverse twin.
+func (g *Graph[T]) setEdgeAttrs(e *edge[T], attrs Attrs) {
+	g.changed()
+	e.attrs = attrs
+	if !g.directed {
+		for twin := e.to.edges[e.from.value]; twin != nil; twin = twin.next {
+			if twin.id == e.id {
+				twin.attrs = attrs
+			}
+		}
+	}
+}
+
+// GetEdge returns the edge between two nodes, including its weight and attributes.
+func (g *Graph[T]) GetEdge(from, to T) (Edge[T], bool) {
+	if fromNode, fromExists := g.nodes[from]; fromExists {
+		if edge, exists := fromNode.edges[to]; exists {
+			return edge.view(), true
+		}
+	}
+	return Edge[T]{}, false
+}
+
 // Traverse performs a breadth-first traversal starting from the given node.
+// See BFS and DFS for traversals with options.
 func (g *Graph[T]) Traverse(start T, visit func(T)) {
 	startNode, exists := g.nodes[start]
 	if !exists {
@@ -226,15 +394,18 @@ func (g *Graph[T]) Nodes() []T {
 }
 
 // Edges returns a slice of all edges in the graph.
+// In a multigraph a pair of nodes appears once for every parallel edge.
 func (g *Graph[T]) Edges() [][2]T {
 	var edges [][2]T
 	seen := make(map[[2]T]bool)
 
 	for fromValue, fromNode := range g.nodes {
-		for toValue := range fromNode.edges {
+		for toValue, head := range fromNode.edges {
 			edgePair := [2]T{fromValue, toValue}
 			if g.directed || !seen[edgePair] {
-				edges = append(edges, edgePair)
+				for e := head; e != nil; e = e.next {
+					edges = append(edges, edgePair)
+				}
 				if !g.directed {
 					seen[[2]T{toValue, fromValue}] = true
 				}
@@ -245,17 +416,22 @@ func (g *Graph[T]) Edges() [][2]T {
 	return edges
 }
 
+// Iterator returns a breadth-first iterator over every node of the graph, one component after another.
 func (g *Graph[T]) Iterator() iterator.Iterator[T] {
+	return g.IteratorWith()
+}
+
+// IteratorWith returns a breadth-first iterator over every node of the graph using the given traversal options.
+// With WithNodeOrder the iteration is deterministic and starts from the smallest node.
+func (g *Graph[T]) IteratorWith(opts ...TraversalOption[T]) iterator.Iterator[T] {
 	nodes := g.Nodes()
-	if len(nodes) == 0 {
-		return &Iterator[T]{
-			visited: make(map[T]bool),
-			queue:   make([]T, 0),
-			graph:   g,
-		}
-	}
+	newTraversal(opts).sortNodes(nodes)
 
-	return NewIterator(g, nodes[0])
+	var start T
+	if len(nodes) > 0 {
+		start = nodes[0]
+	}
+	return NewIterator(g, start, append([]TraversalOption[T]{WithAllComponents[T]()}, opts...)...)
 }
 
 // ForEach applies a function to each node in the graph.
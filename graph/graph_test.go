@@ -195,3 +195,112 @@ func TestForEach(t *testing.T) {
 		}
 	}
 }
+
+func TestNodeAttrs(t *testing.T) {
+	label := NewAttr[string]("label")
+	rank := NewAttr[int]("rank")
+
+	g := New[string](false)
+	g.AddNode("a")
+	if attrs := g.NodeAttrs("a"); attrs.Len() != 0 {
+		t.Errorf("Expected no attributes for new node, got %d", attrs.Len())
+	}
+	if !g.SetNodeAttrs("a", label.With("first")) {
+		t.Error("Expected to set attributes on node a")
+	}
+	snapshot := g.NodeAttrs("a")
+	g.SetNodeAttrs("a", label.With("second"), rank.With(1))
+	if value, ok := label.Get(g.NodeAttrs("a")); !ok || value != "second" {
+		t.Errorf("Expected label 'second', got %q, %v", value, ok)
+	}
+	if value, ok := rank.Get(g.NodeAttrs("a")); !ok || value != 1 {
+		t.Errorf("Expected rank 1, got %v, %v", value, ok)
+	}
+	if value, _ := label.Get(snapshot); value != "first" || snapshot.Len() != 1 {
+		t.Errorf("Expected earlier attributes to be unchanged, got %q", value)
+	}
+
+	if !g.AddNodeWith("b", rank.With(2)) {
+		t.Error("Expected to add node b")
+	}
+	if g.AddNodeWith("b", rank.With(3)) {
+		t.Error("Should not add an existing node")
+	}
+	if value, _ := rank.Get(g.NodeAttrs("b")); value != 2 {
+		t.Errorf("Expected existing node to keep rank 2, got %v", value)
+	}
+
+	other := NewAttr[string]("label")
+	if _, ok := other.Get(g.NodeAttrs("a")); ok {
+		t.Error("Attributes with the same name should be distinct")
+	}
+	if g.SetNodeAttrs("missing", rank.With(1)) {
+		t.Error("Should not set attributes on missing node")
+	}
+	if attrs := g.NodeAttrs("missing"); attrs.Len() != 0 {
+		t.Error("Should not return attributes for missing node")
+	}
+}
+
+func TestEdgeAttrs(t *testing.T) {
+	type route struct {
+		Distance float64
+		Toll     float64
+	}
+	routeAttr := NewAttr[route]("route")
+
+	tests := []struct {
+		name     string
+		directed bool
+	}{
+		{"undirected", false},
+		{"directed", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				g := New[string](tt.directed)
+				g.AddEdge("a", "b", 1)
+
+				if !g.SetEdgeAttrs("a", "b", routeAttr.With(route{Distance: 10, Toll: 2})) {
+					t.Fatal("Expected to set attributes on edge a-b")
+				}
+				r, ok := routeAttr.Get(g.EdgeAttrs("a", "b"))
+				if !ok || r.Distance != 10 {
+					t.Errorf("Unexpected route %v, %v", r, ok)
+				}
+
+				_, ok = routeAttr.Get(g.EdgeAttrs("b", "a"))
+				if ok == tt.directed {
+					t.Errorf("Reverse edge attribute presence should be %v", !tt.directed)
+				}
+
+				e, ok := g.GetEdge("a", "b")
+				if !ok || e.From != "a" || e.To != "b" || e.Weight != 1 {
+					t.Errorf("Unexpected edge %+v", e)
+				}
+
+				toll := WeightFrom[string](routeAttr, func(r route) float64 { return r.Toll })
+				if w := toll(e); w != 2 {
+					t.Errorf("Expected extracted weight 2, got %v", w)
+				}
+				if w := toll(Edge[string]{Weight: 5}); w != 5 {
+					t.Errorf("Expected fallback weight 5, got %v", w)
+				}
+
+				id, added := g.AddEdgeWith("b", "c", 3, routeAttr.With(route{Toll: 7}))
+				if !added {
+					t.Fatal("Expected to add edge b-c")
+				}
+				if e, _ := g.EdgeByID(id); toll(e) != 7 {
+					t.Errorf("Expected edge b-c to have toll 7, got %v", toll(e))
+				}
+
+				if g.SetEdgeAttrs("a", "c", routeAttr.With(route{})) {
+					t.Error("Should not set attributes on missing edge")
+				}
+			},
+		)
+	}
+}
@@ -8,34 +8,42 @@ import (
 type Iterator[T comparable] struct {
 	visited map[T]bool
 	queue   []T
+	depth   map[T]int
 	graph   *Graph[T]
 	start   T
+	opts    *traversal[T]
+	roots   []T // Pending roots of further components
 }
 
-// NewIterator creates a new iterator for breadth-first traversal starting from the given node
-func NewIterator[T comparable](g *Graph[T], start T) iterator.Iterator[T] {
+// NewIterator creates a new iterator for breadth-first traversal starting from the given node.
+// Traversal options such as WithAllComponents, WithMaxDepth or WithNodeOrder may be applied.
+func NewIterator[T comparable](g *Graph[T], start T, opts ...TraversalOption[T]) iterator.Iterator[T] {
 	it := &Iterator[T]{
 		visited: make(map[T]bool),
 		queue:   make([]T, 0),
+		depth:   make(map[T]int),
 		graph:   g,
 		start:   start,
+		opts:    newTraversal(opts),
 	}
-
-	// Only add start node to queue if it exists in the graph
-	if g.HasNode(start) {
-		it.queue = append(it.queue, start)
-	}
-
+	it.Reset()
 	return it
 }
 
 // HasNext returns true if there are more nodes to visit
 func (it *Iterator[T]) HasNext() bool {
-	// Skip nodes that were removed from the graph
-	for len(it.queue) > 0 && !it.graph.HasNode(it.queue[0]) {
-		it.queue = it.queue[1:]
+	for {
+		// Skip nodes that were removed from the graph
+		for len(it.queue) > 0 && !it.graph.HasNode(it.queue[0]) {
+			it.queue = it.queue[1:]
+		}
+		if len(it.queue) > 0 {
+			return true
+		}
+		if !it.nextRoot() {
+			return false
+		}
 	}
-	return len(it.queue) > 0
 }
 
 // Next returns the next node in the breadth-first traversal
@@ -50,9 +58,13 @@ func (it *Iterator[T]) Next() (T, bool) {
 	it.visited[current] = true
 
 	// Add unvisited neighbors that exist in the graph
-	for _, neighbor := range it.graph.Neighbors(current) {
-		if !it.visited[neighbor] && !it.isQueued(neighbor) && it.graph.HasNode(neighbor) {
-			it.queue = append(it.queue, neighbor)
+	depth := it.depth[current] + 1
+	if it.opts.canDescend(depth) {
+		for _, neighbor := range it.graph.neighbors(it.opts, current) {
+			if !it.visited[neighbor] && !it.isQueued(neighbor) && it.graph.HasNode(neighbor) {
+				it.queue = append(it.queue, neighbor)
+				it.depth[neighbor] = depth
+			}
 		}
 	}
 
@@ -62,12 +74,23 @@ func (it *Iterator[T]) Next() (T, bool) {
 // Reset restarts the iteration from the original start node
 func (it *Iterator[T]) Reset() {
 	it.visited = make(map[T]bool)
+	it.depth = make(map[T]int)
 	it.queue = it.queue[:0]
+	it.roots = it.graph.roots(it.opts, it.start)
+}
 
-	// Restart from original start node if it exists
-	if it.graph.HasNode(it.start) {
-		it.queue = append(it.queue, it.start)
+// nextRoot queues the next unvisited root and reports whether one was found.
+func (it *Iterator[T]) nextRoot() bool {
+	for len(it.roots) > 0 {
+		root := it.roots[0]
+		it.roots = it.roots[1:]
+		if !it.visited[root] && it.graph.HasNode(root) {
+			it.queue = append(it.queue, root)
+			it.depth[root] = 0
+			return true
+		}
 	}
+	return false
 }
 
 // isQueued checks if a node is already in the queue to prevent duplicates
@@ -79,3 +102,79 @@ func (it *Iterator[T]) isQueued(node T) bool {
 	}
 	return false
 }
+
+// DFSIterator implements iterator.Iterator for Graph using depth-first traversal in pre-order
+type DFSIterator[T comparable] struct {
+	visited map[T]bool
+	stack   []dfsEntry[T]
+	graph   *Graph[T]
+	start   T
+	opts    *traversal[T]
+	roots   []T // Pending roots of further components
+}
+
+type dfsEntry[T comparable] struct {
+	value T
+	depth int
+}
+
+// NewDFSIterator creates a new iterator for depth-first traversal starting from the given node.
+func NewDFSIterator[T comparable](g *Graph[T], start T, opts ...TraversalOption[T]) iterator.Iterator[T] {
+	it := &DFSIterator[T]{
+		graph: g,
+		start: start,
+		opts:  newTraversal(opts),
+	}
+	it.Reset()
+	return it
+}
+
+// HasNext returns true if there are more nodes to visit
+func (it *DFSIterator[T]) HasNext() bool {
+	for {
+		// Drop entries that were already visited or removed from the graph
+		for len(it.stack) > 0 {
+			top := it.stack[len(it.stack)-1].value
+			if !it.visited[top] && it.graph.HasNode(top) {
+				return true
+			}
+			it.stack = it.stack[:len(it.stack)-1]
+		}
+		if len(it.roots) == 0 {
+			return false
+		}
+		it.stack = append(it.stack, dfsEntry[T]{value: it.roots[0]})
+		it.roots = it.roots[1:]
+	}
+}
+
+// Next returns the next node in the depth-first traversal
+func (it *DFSIterator[T]) Next() (T, bool) {
+	if !it.HasNext() {
+		var zero T
+		return zero, false
+	}
+
+	current := it.stack[len(it.stack)-1]
+	it.stack = it.stack[:len(it.stack)-1]
+	it.visited[current.value] = true
+
+	// Push neighbors in reverse so that the first one is visited next
+	if it.opts.canDescend(current.depth + 1) {
+		neighbors := it.graph.neighbors(it.opts, current.value)
+		for i := len(neighbors) - 1; i >= 0; i-- {
+			if !it.visited[neighbors[i]] {
+				it.stack = append(it.stack, dfsEntry[T]{value: neighbors[i], depth: current.depth + 1})
+			}
+		}
+	}
+
+	return current.value, true
+}
+
+// Reset restarts the iteration from the original start node
+func (it *DFSIterator[T]) Reset() {
+	it.visited = make(map[T]bool)
+	it.stack = it.stack[:0]
+	it.roots = it.graph.roots(it.opts, it.start)
+}
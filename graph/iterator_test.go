@@ -3,6 +3,8 @@ package graph
 import (
 	"sort"
 	"testing"
+
+	"github.com/idsulik/go-collections/v3/internal/slices"
 )
 
 func TestIterator_EmptyGraph(t *testing.T) {
@@ -284,3 +286,130 @@ func TestIterator_ModificationDuringIteration(t *testing.T) {
 		},
 	)
 }
+
+func TestIterator_AllComponents(t *testing.T) {
+	g := New[string](false)
+	g.AddEdge("A", "B", 1.0)
+	g.AddEdge("C", "D", 1.0)
+	g.AddNode("E")
+
+	t.Run(
+		"Graph iterator should visit every component", func(t *testing.T) {
+			it := g.Iterator()
+			visited := make(map[string]bool)
+			for it.HasNext() {
+				value, _ := it.Next()
+				if visited[value] {
+					t.Errorf("Node %s visited twice", value)
+				}
+				visited[value] = true
+			}
+			if len(visited) != 5 {
+				t.Errorf("Expected 5 nodes to be visited, got %d", len(visited))
+			}
+		},
+	)
+
+	t.Run(
+		"Ordered iterator should be deterministic", func(t *testing.T) {
+			it := g.IteratorWith(WithNodeOrder(func(a, b string) bool { return a < b }))
+			var visited []string
+			for it.HasNext() {
+				value, _ := it.Next()
+				visited = append(visited, value)
+			}
+			it.Reset()
+			var again []string
+			for it.HasNext() {
+				value, _ := it.Next()
+				again = append(again, value)
+			}
+
+			expected := []string{"A", "B", "C", "D", "E"}
+			if !slices.Equal(visited, expected) || !slices.Equal(again, expected) {
+				t.Errorf("Expected %v, got %v and %v after reset", expected, visited, again)
+			}
+		},
+	)
+}
+
+func TestDFSIterator(t *testing.T) {
+	g := treeGraph()
+
+	t.Run(
+		"Should visit nodes in pre-order", func(t *testing.T) {
+			it := NewDFSIterator(g, 1, WithNodeOrder(lessInt), WithAllComponents[int]())
+			var visited []int
+			for it.HasNext() {
+				value, _ := it.Next()
+				visited = append(visited, value)
+			}
+
+			expected := []int{1, 2, 4, 5, 3, 6, 7, 8}
+			if !slices.Equal(visited, expected) {
+				t.Errorf("Expected %v, got %v", expected, visited)
+			}
+
+			it.Reset()
+			if value, _ := it.Next(); value != 1 {
+				t.Errorf("Expected 1 after reset, got %d", value)
+			}
+		},
+	)
+
+	t.Run(
+		"Should respect max depth", func(t *testing.T) {
+			it := NewDFSIterator(g, 1, WithNodeOrder(lessInt), WithMaxDepth[int](1))
+			var visited []int
+			for it.HasNext() {
+				value, _ := it.Next()
+				visited = append(visited, value)
+			}
+			if !slices.Equal(visited, []int{1, 2, 3}) {
+				t.Errorf("Expected [1 2 3], got %v", visited)
+			}
+		},
+	)
+
+	t.Run(
+		"Should skip removed nodes", func(t *testing.T) {
+			g := treeGraph()
+			it := NewDFSIterator(g, 1, WithNodeOrder(lessInt))
+			it.Next()
+			g.RemoveNode(2)
+			var visited []int
+			for it.HasNext() {
+				value, _ := it.Next()
+				visited = append(visited, value)
+			}
+			if !slices.Equal(visited, []int{3, 6}) {
+				t.Errorf("Expected [3 6], got %v", visited)
+			}
+		},
+	)
+
+	t.Run(
+		"Should handle missing start node", func(t *testing.T) {
+			it := NewDFSIterator(g, 42)
+			if it.HasNext() {
+				t.Error("HasNext() should return false for missing start node")
+			}
+			if _, ok := it.Next(); ok {
+				t.Error("Next() should return false for missing start node")
+			}
+		},
+	)
+}
+
+func TestIterator_MaxDepth(t *testing.T) {
+	g := treeGraph()
+	it := NewIterator(g, 1, WithMaxDepth[int](1), WithNodeOrder(lessInt))
+	var visited []int
+	for it.HasNext() {
+		value, _ := it.Next()
+		visited = append(visited, value)
+	}
+	if !slices.Equal(visited, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", visited)
+	}
+}
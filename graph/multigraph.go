@@ -0,0 +1,122 @@
+package graph
+
+// NewMultigraph creates a new Graph that allows parallel edges between the same pair of nodes.
+// Each edge is identified by the ID returned from AddEdgeWithID.
+func NewMultigraph[T comparable](directed bool) *Graph[T] {
+	g := New[T](directed)
+	g.multi = true
+	return g
+}
+
+// IsMultigraph returns true if the graph allows parallel edges.
+func (g *Graph[T]) IsMultigraph() bool {
+	return g.multi
+}
+
+// EdgesBetween returns all edges leading from one node to another, in insertion order.
+// For undirected graphs the edges are oriented from the first argument to the second.
+func (g *Graph[T]) EdgesBetween(from, to T) []Edge[T] {
+	fromNode, exists := g.nodes[from]
+	if !exists {
+		return nil
+	}
+
+	var edges []Edge[T]
+	for e := fromNode.edges[to]; e != nil; e = e.next {
+		edges = append(edges, e.view())
+	}
+	return edges
+}
+
+// EdgeByID returns the edge with the given ID.
+func (g *Graph[T]) EdgeByID(id int) (Edge[T], bool) {
+	e, exists := g.edges[id]
+	if !exists {
+		return Edge[T]{}, false
+	}
+	return e.view(), true
+}
+
+// SetEdgeAttrsByID sets attributes of the edge with the given ID, keeping its other attributes,
+// and returns true if the edge exists.
+func (g *Graph[T]) SetEdgeAttrsByID(id int, attrs ...AttrValue) bool {
+	e, exists := g.edges[id]
+	if !exists {
+		return false
+	}
+	g.setEdgeAttrs(e, e.attrs.with(attrs))
+	return true
+}
+
+// RemoveEdgeByID removes a single edge, leaving any parallel edges in place.
+// Returns true if the edge was removed, false if not found.
+func (g *Graph[T]) RemoveEdgeByID(id int) bool {
+	e, exists := g.edges[id]
+	if !exists {
+		return false
+	}
+	g.changed()
+
+	from, to := e.from, e.to
+	unlinkEdge(from.edges, to.value, id)
+	if g.directed {
+		// Incoming edges share the chain of outgoing edges
+		if head, exists := from.edges[to.value]; exists {
+			to.incoming[from.value] = head
+		} else {
+			delete(to.incoming, from.value)
+		}
+	} else if from != to {
+		unlinkEdge(to.edges, from.value, id)
+	}
+
+	delete(g.edges, id)
+	return true
+}
+
+// unlinkEdge removes the edge with the given ID from the chain stored under key.
+func unlinkEdge[T comparable](edges map[T]*edge[T], key T, id int) {
+	var prev *edge[T]
+	for e := edges[key]; e != nil; prev, e = e, e.next {
+		if e.id != id {
+			continue
+		}
+		switch {
+		case prev != nil:
+			prev.next = e.next
+		case e.next != nil:
+			edges[key] = e.next
+		default:
+			delete(edges, key)
+		}
+		return
+	}
+}
+
+// SelfLoops returns all edges that start and end at the same node.
+func (g *Graph[T]) SelfLoops() []Edge[T] {
+	var loops []Edge[T]
+	for value, n := range g.nodes {
+		for e := n.edges[value]; e != nil; e = e.next {
+			loops = append(loops, e.view())
+		}
+	}
+	return loops
+}
+
+// RemoveSelfLoops removes every self-loop and returns the number of edges removed.
+func (g *Graph[T]) RemoveSelfLoops() int {
+	removed := 0
+	for value, n := range g.nodes {
+		for e := n.edges[value]; e != nil; e = e.next {
+			delete(g.edges, e.id)
+			removed++
+		}
+		delete(n.edges, value)
+		delete(n.incoming, value)
+	}
+	if removed > 0 {
+		g.changed()
+	}
+	return removed
+}
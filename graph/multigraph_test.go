@@ -0,0 +1,191 @@
+package graph
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestMultigraph_ParallelEdges(t *testing.T) {
+	tests := []struct {
+		name     string
+		directed bool
+	}{
+		{"undirected", false},
+		{"directed", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				g := NewMultigraph[string](tt.directed)
+				if !g.IsMultigraph() {
+					t.Error("Expected multigraph")
+				}
+
+				first, ok := g.AddEdgeWithID("AMS", "LHR", 45)
+				if !ok {
+					t.Fatal("Expected to add first flight")
+				}
+				second, ok := g.AddEdgeWithID("AMS", "LHR", 60)
+				if !ok || second == first {
+					t.Fatalf("Expected second flight with a distinct ID, got %d (first %d)", second, first)
+				}
+				if !g.AddEdge("AMS", "LHR", 90) {
+					t.Error("AddEdge should add a parallel edge in a multigraph")
+				}
+
+				between := g.EdgesBetween("AMS", "LHR")
+				if len(between) != 3 {
+					t.Fatalf("Expected 3 parallel edges, got %d", len(between))
+				}
+				if between[0].ID != first || between[0].Weight != 45 || between[1].Weight != 60 {
+					t.Errorf("Edges should be returned in insertion order, got %+v", between)
+				}
+				if len(g.Edges()) != 3 {
+					t.Errorf("Expected 3 edges, got %d", len(g.Edges()))
+				}
+				if len(g.Neighbors("AMS")) != 1 {
+					t.Errorf("Expected 1 distinct neighbor, got %v", g.Neighbors("AMS"))
+				}
+
+				reverse := g.EdgesBetween("LHR", "AMS")
+				if tt.directed && len(reverse) != 0 {
+					t.Errorf("Directed multigraph should have no reverse edges, got %+v", reverse)
+				}
+				if !tt.directed && (len(reverse) != 3 || reverse[1].ID != second || reverse[1].From != "LHR") {
+					t.Errorf("Undirected edges should be visible in reverse, got %+v", reverse)
+				}
+
+				flight := NewAttr[string]("flight")
+				if !g.SetEdgeAttrsByID(second, flight.With("KL1001")) {
+					t.Error("Expected to set attributes by ID")
+				}
+				if e, ok := g.EdgeByID(second); !ok || e.Weight != 60 {
+					t.Errorf("Unexpected edge %+v", e)
+				} else if number, _ := flight.Get(e.Attrs); number != "KL1001" {
+					t.Errorf("Expected flight KL1001, got %q", number)
+				}
+
+				if !g.RemoveEdgeByID(first) {
+					t.Error("Expected to remove edge by ID")
+				}
+				if g.RemoveEdgeByID(first) {
+					t.Error("Edge should not be removed twice")
+				}
+				if _, ok := g.EdgeByID(first); ok {
+					t.Error("Removed edge should not be found")
+				}
+				if w, _ := g.GetEdgeWeight("AMS", "LHR"); w != 60 {
+					t.Errorf("Expected remaining first edge weight 60, got %v", w)
+				}
+				if !tt.directed && len(g.EdgesBetween("LHR", "AMS")) != 2 {
+					t.Error("Removing by ID should remove the reverse direction as well")
+				}
+
+				if !g.RemoveEdge("AMS", "LHR") {
+					t.Error("Expected to remove remaining edges")
+				}
+				if g.HasEdge("AMS", "LHR") || len(g.Edges()) != 0 {
+					t.Error("RemoveEdge should remove all parallel edges")
+				}
+				if _, ok := g.EdgeByID(second); ok {
+					t.Error("IDs of removed edges should be forgotten")
+				}
+			},
+		)
+	}
+}
+
+func TestMultigraph_RemoveLastByID(t *testing.T) {
+	g := NewMultigraph[int](true)
+	id, _ := g.AddEdgeWithID(1, 2, 1)
+
+	if !g.RemoveEdgeByID(id) {
+		t.Fatal("Expected to remove edge")
+	}
+	if g.HasEdge(1, 2) {
+		t.Error("Edge should be gone")
+	}
+	if len(g.nodes[2].incoming) != 0 {
+		t.Error("Incoming edges should be cleaned up")
+	}
+}
+
+func TestSimpleGraph_EdgeIDs(t *testing.T) {
+	g := New[int](false)
+	id, ok := g.AddEdgeWithID(1, 2, 3)
+	if !ok {
+		t.Fatal("Expected to add edge")
+	}
+	if _, ok := g.AddEdgeWithID(2, 1, 3); ok {
+		t.Error("Simple graph should reject a second edge between the same nodes")
+	}
+	if e, ok := g.EdgeByID(id); !ok || e.From != 1 || e.To != 2 {
+		t.Errorf("Unexpected edge %+v", e)
+	}
+
+	g.RemoveNode(2)
+	if _, ok := g.EdgeByID(id); ok {
+		t.Error("Removing a node should forget the IDs of its edges")
+	}
+}
+
+func TestSelfLoops(t *testing.T) {
+	g := NewMultigraph[int](false)
+	g.AddEdge(1, 1, 2)
+	g.AddEdge(1, 1, 3)
+	g.AddEdge(1, 2, 1)
+
+	if loops := g.SelfLoops(); len(loops) != 2 {
+		t.Errorf("Expected 2 self-loops, got %+v", loops)
+	}
+	if len(g.Edges()) != 3 {
+		t.Errorf("Undirected self-loops should be counted once, got %v", g.Edges())
+	}
+	if removed := g.RemoveSelfLoops(); removed != 2 {
+		t.Errorf("Expected to remove 2 self-loops, removed %d", removed)
+	}
+	if g.HasEdge(1, 1) || !g.HasEdge(1, 2) {
+		t.Error("Only self-loops should be removed")
+	}
+	if len(g.edges) != 1 {
+		t.Errorf("Expected 1 edge ID left, got %d", len(g.edges))
+	}
+}
+
+func TestMultigraph_MaxFlow(t *testing.T) {
+	g := NewMultigraph[string](true)
+	g.AddEdge("s", "t", 2)
+	g.AddEdge("s", "t", 3)
+
+	result, err := g.Dinic("s", "t")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if math.Abs(result.Value-5) > 1e-9 {
+		t.Errorf("Parallel capacities should add up to 5, got %v", result.Value)
+	}
+	if math.Abs(result.Flow[[2]string{"s", "t"}]-5) > 1e-9 {
+		t.Errorf("Expected combined flow 5, got %v", result.Flow)
+	}
+}
+
+func TestMultigraph_JSON(t *testing.T) {
+	g := NewMultigraph[int](false)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(1, 2, 2)
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var decoded Graph[int]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !decoded.IsMultigraph() || len(decoded.EdgesBetween(1, 2)) != 2 {
+		t.Errorf("Expected multigraph with 2 parallel edges, got %s", data)
+	}
+}
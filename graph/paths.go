@@ -0,0 +1,400 @@
+package graph
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/idsulik/go-collections/v3/internal/slices"
+	"github.com/idsulik/go-collections/v3/iterator"
+	"github.com/idsulik/go-collections/v3/priorityqueue"
+)
+
+// arcWeights returns the weight of every stored edge, in the order of the targets array.
+// It returns ErrNegativeWeight if the WeightFunc yields a negative weight.
+func (f *Frozen[T]) arcWeights(weight WeightFunc[T]) ([]float64, error) {
+	weights := make([]float64, len(f.targets))
+	for u := range f.values {
+		for a := f.offsets[u]; a < f.offsets[u+1]; a++ {
+			w := weight(f.edgeView(u, a))
+			if w < 0 {
+				return nil, ErrNegativeWeight
+			}
+			weights[a] = w
+		}
+	}
+	return weights, nil
+}
+
+// shortestPathTree holds the result of a single-source shortest path search.
+type shortestPathTree struct {
+	order []int     // Reached nodes in non-decreasing distance
+	dist  []float64 // +Inf for unreachable nodes
+	sigma []float64 // Number of shortest paths from the source
+	preds [][]int   // Predecessors on shortest paths
+}
+
+type distEntry struct {
+	node int
+	dist float64
+}
+
+// dijkstra computes shortest paths from s over the non-negative arc weights returned by arcWeights.
+// Arcs with infinite weight and nodes marked in removed, which may be nil, are treated as absent.
+func (f *Frozen[T]) dijkstra(weights []float64, removed []bool, s int) *shortestPathTree {
+	n := len(f.values)
+	tree := &shortestPathTree{
+		dist:  make([]float64, n),
+		sigma: make([]float64, n),
+		preds: make([][]int, n),
+	}
+	for i := range tree.dist {
+		tree.dist[i] = math.Inf(1)
+	}
+	tree.dist[s] = 0
+	tree.sigma[s] = 1
+
+	settled := make([]bool, n)
+	pq := priorityqueue.New[distEntry](
+		func(a, b distEntry) bool {
+			return a.dist < b.dist
+		},
+	)
+	pq.Push(distEntry{node: s})
+
+	for !pq.IsEmpty() {
+		entry, _ := pq.Pop()
+		u := entry.node
+		if settled[u] {
+			continue
+		}
+		settled[u] = true
+		tree.order = append(tree.order, u)
+
+		for a := f.offsets[u]; a < f.offsets[u+1]; a++ {
+			v := f.targets[a]
+			if math.IsInf(weights[a], 1) || (removed != nil && removed[v]) {
+				continue
+			}
+			d := tree.dist[u] + weights[a]
+			switch {
+			case d < tree.dist[v]:
+				tree.dist[v] = d
+				tree.sigma[v] = tree.sigma[u]
+				tree.preds[v] = append(tree.preds[v][:0], u)
+				pq.Push(distEntry{node: v, dist: d})
+			case d == tree.dist[v] && !settled[v]:
+				tree.sigma[v] += tree.sigma[u]
+				tree.preds[v] = append(tree.preds[v], u)
+			}
+		}
+	}
+	return tree
+}
+
+// Path is a walk through the graph together with its total weight.
+type Path[T comparable] struct {
+	Nodes  []T
+	Weight float64
+}
+
+// ShortestPath returns a path of minimum total weight between two nodes using Dijkstra's algorithm.
+// Between parallel edges the lightest is used. It returns ErrNodeNotFound for unknown nodes,
+// ErrNegativeWeight if an edge has negative weight and ErrNoPath if to is not reachable.
+func (g *Graph[T]) ShortestPath(from, to T) (Path[T], error) {
+	return g.ShortestPathFunc(from, to, EdgeWeight[T])
+}
+
+// ShortestPathFunc is like ShortestPath but reads edge weights with the given WeightFunc.
+func (g *Graph[T]) ShortestPathFunc(from, to T, weight WeightFunc[T]) (Path[T], error) {
+	return g.frozen().ShortestPathFunc(from, to, weight)
+}
+
+// KShortestPaths returns up to k loopless paths between two nodes in order of increasing weight,
+// using Yen's algorithm. Fewer paths are returned if fewer exist. Paths are distinguished by their
+// nodes, so parallel edges do not produce additional paths.
+func (g *Graph[T]) KShortestPaths(from, to T, k int) ([]Path[T], error) {
+	return g.KShortestPathsFunc(from, to, k, EdgeWeight[T])
+}
+
+// KShortestPathsFunc is like KShortestPaths but reads edge weights with the given WeightFunc.
+func (g *Graph[T]) KShortestPathsFunc(from, to T, k int, weight WeightFunc[T]) ([]Path[T], error) {
+	return g.frozen().KShortestPathsFunc(from, to, k, weight)
+}
+
+// ShortestPath returns a path of minimum total weight between two nodes.
+// See Graph.ShortestPath for details.
+func (f *Frozen[T]) ShortestPath(from, to T) (Path[T], error) {
+	return f.ShortestPathFunc(from, to, EdgeWeight[T])
+}
+
+// ShortestPathFunc is like ShortestPath but reads edge weights with the given WeightFunc.
+func (f *Frozen[T]) ShortestPathFunc(from, to T, weight WeightFunc[T]) (Path[T], error) {
+	paths, err := f.KShortestPathsFunc(from, to, 1, weight)
+	if err != nil {
+		return Path[T]{}, err
+	}
+	if len(paths) == 0 {
+		return Path[T]{}, ErrNoPath
+	}
+	return paths[0], nil
+}
+
+// KShortestPaths returns up to k loopless paths between two nodes in order of increasing weight.
+// See Graph.KShortestPaths for details.
+func (f *Frozen[T]) KShortestPaths(from, to T, k int) ([]Path[T], error) {
+	return f.KShortestPathsFunc(from, to, k, EdgeWeight[T])
+}
+
+// yenPath is a path of node indices with the distance from the source to each of its nodes.
+type yenPath struct {
+	nodes []int
+	dist  []float64
+}
+
+func (p yenPath) weight() float64 {
+	return p.dist[len(p.dist)-1]
+}
+
+// key returns the node indices of the path encoded as a string, to detect duplicate paths.
+func (p yenPath) key() string {
+	buf := make([]byte, len(p.nodes)*binary.MaxVarintLen64)
+	n := 0
+	for _, u := range p.nodes {
+		n += binary.PutUvarint(buf[n:], uint64(u))
+	}
+	return string(buf[:n])
+}
+
+// KShortestPathsFunc is like KShortestPaths but reads edge weights with the given WeightFunc.
+func (f *Frozen[T]) KShortestPathsFunc(from, to T, k int, weight WeightFunc[T]) ([]Path[T], error) {
+	s, fromExists := f.index[from]
+	t, toExists := f.index[to]
+	if !fromExists || !toExists {
+		return nil, ErrNodeNotFound
+	}
+	weights, err := f.arcWeights(weight)
+	if err != nil {
+		return nil, err
+	}
+	if k <= 0 {
+		return nil, nil
+	}
+
+	first, ok := f.pathTo(f.dijkstra(weights, nil, s), t)
+	if !ok {
+		return nil, nil
+	}
+	accepted := []yenPath{first}
+	seen := map[string]bool{first.key(): true}
+	candidates := priorityqueue.New[yenPath](
+		func(a, b yenPath) bool {
+			if a.weight() != b.weight() {
+				return a.weight() < b.weight()
+			}
+			return len(a.nodes) < len(b.nodes)
+		},
+	)
+
+	removed := make([]bool, len(f.values))
+	for len(accepted) < k {
+		prev := accepted[len(accepted)-1]
+		for i := 0; i < len(prev.nodes)-1; i++ {
+			spur := prev.nodes[i]
+			root := prev.nodes[:i+1]
+
+			// Block the next edge of every accepted path sharing this root,
+			// and the root itself so that spur paths stay loopless
+			var blocked []blockedArc
+			for _, p := range accepted {
+				if len(p.nodes) > i+1 && slices.Equal(p.nodes[:i+1], root) {
+					blocked = f.blockArcs(weights, spur, p.nodes[i+1], blocked)
+				}
+			}
+			for _, u := range root[:i] {
+				removed[u] = true
+			}
+
+			spurPath, ok := f.pathTo(f.dijkstra(weights, removed, spur), t)
+
+			for _, u := range root[:i] {
+				removed[u] = false
+			}
+			for _, b := range blocked {
+				weights[b.arc] = b.weight
+			}
+
+			if !ok {
+				continue
+			}
+			candidate := yenPath{
+				nodes: append(append([]int(nil), root[:i]...), spurPath.nodes...),
+				dist:  append([]float64(nil), prev.dist[:i]...),
+			}
+			for _, d := range spurPath.dist {
+				candidate.dist = append(candidate.dist, prev.dist[i]+d)
+			}
+			if key := candidate.key(); !seen[key] {
+				seen[key] = true
+				candidates.Push(candidate)
+			}
+		}
+
+		next, ok := candidates.Pop()
+		if !ok {
+			break
+		}
+		accepted = append(accepted, next)
+	}
+
+	paths := make([]Path[T], len(accepted))
+	for i, p := range accepted {
+		paths[i] = Path[T]{Nodes: make([]T, len(p.nodes)), Weight: p.weight()}
+		for j, u := range p.nodes {
+			paths[i].Nodes[j] = f.values[u]
+		}
+	}
+	return paths, nil
+}
+
+// blockedArc remembers the weight of an arc that was temporarily removed.
+type blockedArc struct {
+	arc    int
+	weight float64
+}
+
+// blockArcs removes the arcs from u to v by giving them infinite weight and appends them to blocked.
+func (f *Frozen[T]) blockArcs(weights []float64, u, v int, blocked []blockedArc) []blockedArc {
+	row := f.targets[f.offsets[u]:f.offsets[u+1]]
+	for k := sort.SearchInts(row, v); k < len(row) && row[k] == v; k++ {
+		a := f.offsets[u] + k
+		if !math.IsInf(weights[a], 1) {
+			blocked = append(blocked, blockedArc{arc: a, weight: weights[a]})
+			weights[a] = math.Inf(1)
+		}
+	}
+	return blocked
+}
+
+// pathTo extracts the path to t from a shortest path tree.
+func (f *Frozen[T]) pathTo(tree *shortestPathTree, t int) (yenPath, bool) {
+	if math.IsInf(tree.dist[t], 1) {
+		return yenPath{}, false
+	}
+
+	var p yenPath
+	for v := t; ; v = tree.preds[v][0] {
+		p.nodes = append(p.nodes, v)
+		p.dist = append(p.dist, tree.dist[v])
+		if len(tree.preds[v]) == 0 {
+			break
+		}
+	}
+	for i, j := 0, len(p.nodes)-1; i < j; i, j = i+1, j-1 {
+		p.nodes[i], p.nodes[j] = p.nodes[j], p.nodes[i]
+		p.dist[i], p.dist[j] = p.dist[j], p.dist[i]
+	}
+	return p, true
+}
+
+// SimplePaths returns a lazy iterator over the paths from one node to another that visit no node
+// twice, as the sequence of nodes. Paths longer than maxLength edges are skipped; a negative
+// maxLength means no limit. Paths are produced depth-first as the iterator advances, so the graph
+// must not be modified while iterating. No paths are produced when from equals to.
+func (g *Graph[T]) SimplePaths(from, to T, maxLength int) iterator.Iterator[[]T] {
+	return newSimplePathIterator(g.HasNode, g.Neighbors, from, to, maxLength)
+}
+
+// SimplePaths returns a lazy iterator over the paths from one node to another that visit no node twice.
+// See Graph.SimplePaths for details.
+func (f *Frozen[T]) SimplePaths(from, to T, maxLength int) iterator.Iterator[[]T] {
+	return newSimplePathIterator(f.HasNode, f.Neighbors, from, to, maxLength)
+}
+
+// SimplePathIterator enumerates simple paths with an explicit depth-first search stack.
+type SimplePathIterator[T comparable] struct {
+	hasNode   func(T) bool
+	neighbors func(T) []T
+	from, to  T
+	maxLength int
+
+	path    []T
+	onPath  map[T]bool
+	pending [][]T // Unexplored neighbors of each node on the path
+	next    []T
+}
+
+func newSimplePathIterator[T comparable](
+	hasNode func(T) bool,
+	neighbors func(T) []T,
+	from, to T,
+	maxLength int,
+) *SimplePathIterator[T] {
+	it := &SimplePathIterator[T]{
+		hasNode:   hasNode,
+		neighbors: neighbors,
+		from:      from,
+		to:        to,
+		maxLength: maxLength,
+	}
+	it.Reset()
+	return it
+}
+
+// HasNext returns true if there is another path.
+func (it *SimplePathIterator[T]) HasNext() bool {
+	if it.next != nil {
+		return true
+	}
+
+	for len(it.pending) > 0 {
+		top := len(it.pending) - 1
+		if len(it.pending[top]) == 0 {
+			delete(it.onPath, it.path[top])
+			it.path = it.path[:top]
+			it.pending = it.pending[:top]
+			continue
+		}
+
+		v := it.pending[top][0]
+		it.pending[top] = it.pending[top][1:]
+		if it.onPath[v] {
+			continue
+		}
+		if v == it.to {
+			it.next = append(append([]T(nil), it.path...), v)
+			return true
+		}
+		// Descend only if the target can still be reached within the limit
+		if it.maxLength < 0 || len(it.path)+1 <= it.maxLength {
+			it.onPath[v] = true
+			it.path = append(it.path, v)
+			it.pending = append(it.pending, it.neighbors(v))
+		}
+	}
+	return false
+}
+
+// Next returns the next path.
+func (it *SimplePathIterator[T]) Next() ([]T, bool) {
+	if !it.HasNext() {
+		return nil, false
+	}
+	path := it.next
+	it.next = nil
+	return path, true
+}
+
+// Reset restarts the enumeration from the first path.
+func (it *SimplePathIterator[T]) Reset() {
+	it.path = it.path[:0]
+	it.onPath = make(map[T]bool)
+	it.pending = it.pending[:0]
+	it.next = nil
+	if it.from == it.to || !it.hasNode(it.from) || !it.hasNode(it.to) || it.maxLength == 0 {
+		return
+	}
+	it.path = append(it.path, it.from)
+	it.onPath[it.from] = true
+	it.pending = append(it.pending, it.neighbors(it.from))
+}
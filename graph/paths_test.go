@@ -0,0 +1,191 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/idsulik/go-collections/v3/internal/slices"
+)
+
+// yenGraph is the directed example network from the Wikipedia article on Yen's algorithm.
+func yenGraph() *Graph[string] {
+	g := New[string](true)
+	for _, e := range []struct {
+		from, to string
+		weight   float64
+	}{
+		{"C", "D", 3}, {"C", "E", 2}, {"D", "F", 4}, {"E", "D", 1},
+		{"E", "F", 2}, {"E", "G", 3}, {"F", "G", 2}, {"F", "H", 1}, {"G", "H", 2},
+	} {
+		g.AddEdge(e.from, e.to, e.weight)
+	}
+	return g
+}
+
+func TestShortestPath(t *testing.T) {
+	g := yenGraph()
+	path, err := g.ShortestPath("C", "H")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !slices.Equal(path.Nodes, []string{"C", "E", "F", "H"}) || path.Weight != 5 {
+		t.Errorf("Expected C-E-F-H with weight 5, got %+v", path)
+	}
+
+	hops, err := g.ShortestPathFunc("C", "H", func(Edge[string]) float64 { return 1 })
+	if err != nil || len(hops.Nodes) != 4 || hops.Weight != 3 {
+		t.Errorf("Expected a path of 3 hops, got %+v (%v)", hops, err)
+	}
+
+	if _, err := g.ShortestPath("H", "C"); err != ErrNoPath {
+		t.Errorf("Expected ErrNoPath, got %v", err)
+	}
+	if _, err := g.ShortestPath("C", "X"); err != ErrNodeNotFound {
+		t.Errorf("Expected ErrNodeNotFound, got %v", err)
+	}
+	if path, err := g.ShortestPath("C", "C"); err != nil || len(path.Nodes) != 1 || path.Weight != 0 {
+		t.Errorf("Expected the trivial path, got %+v (%v)", path, err)
+	}
+
+	g.AddEdge("C", "Z", -1)
+	if _, err := g.ShortestPath("C", "H"); err != ErrNegativeWeight {
+		t.Errorf("Expected ErrNegativeWeight, got %v", err)
+	}
+}
+
+func TestKShortestPaths(t *testing.T) {
+	paths, err := yenGraph().KShortestPaths("C", "H", 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []Path[string]{
+		{Nodes: []string{"C", "E", "F", "H"}, Weight: 5},
+		{Nodes: []string{"C", "E", "G", "H"}, Weight: 7},
+		{Nodes: []string{"C", "D", "F", "H"}, Weight: 8},
+	}
+	if len(paths) != len(expected) {
+		t.Fatalf("Expected %d paths, got %+v", len(expected), paths)
+	}
+	for i := range expected {
+		if paths[i].Weight != expected[i].Weight {
+			t.Errorf("Path %d: expected weight %v, got %+v", i, expected[i].Weight, paths[i])
+		}
+	}
+	// The first two paths are unique; the third ties with C-E-D-F-H
+	for i := 0; i < 2; i++ {
+		if !slices.Equal(paths[i].Nodes, expected[i].Nodes) {
+			t.Errorf("Path %d: expected %v, got %v", i, expected[i].Nodes, paths[i].Nodes)
+		}
+	}
+}
+
+func TestKShortestPaths_AllPaths(t *testing.T) {
+	// Asking for more paths than exist returns every simple path, in order of weight
+	g := New[int](false)
+	for _, e := range [][3]int{{1, 2, 1}, {2, 4, 1}, {1, 3, 2}, {3, 4, 2}, {2, 3, 1}} {
+		g.AddEdge(e[0], e[1], float64(e[2]))
+	}
+
+	paths, err := g.KShortestPaths(1, 4, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var enumerated []string
+	for it := g.SimplePaths(1, 4, -1); it.HasNext(); {
+		path, _ := it.Next()
+		enumerated = append(enumerated, fmt.Sprint(path))
+	}
+	if len(paths) != len(enumerated) {
+		t.Fatalf("Expected %d paths, got %+v", len(enumerated), paths)
+	}
+
+	var found []string
+	for i, p := range paths {
+		found = append(found, fmt.Sprint(p.Nodes))
+		if i > 0 && p.Weight < paths[i-1].Weight {
+			t.Errorf("Paths are not ordered by weight: %+v", paths)
+		}
+		total := 0.0
+		for j := 1; j < len(p.Nodes); j++ {
+			w, ok := g.GetEdgeWeight(p.Nodes[j-1], p.Nodes[j])
+			if !ok {
+				t.Fatalf("Path %v uses a missing edge", p.Nodes)
+			}
+			total += w
+		}
+		if math.Abs(total-p.Weight) > 1e-9 {
+			t.Errorf("Path %v: expected weight %v, got %v", p.Nodes, total, p.Weight)
+		}
+	}
+	sort.Strings(found)
+	sort.Strings(enumerated)
+	if !slices.Equal(found, enumerated) {
+		t.Errorf("Expected paths %v, got %v", enumerated, found)
+	}
+
+	if paths, _ := g.KShortestPaths(1, 4, 0); len(paths) != 0 {
+		t.Errorf("Expected no paths for k=0, got %v", paths)
+	}
+}
+
+func TestSimplePaths(t *testing.T) {
+	g := completeGraph(4)
+
+	tests := []struct {
+		maxLength int
+		expected  int
+	}{
+		{-1, 5}, // 0-3, 0-1-3, 0-2-3, 0-1-2-3, 0-2-1-3
+		{0, 0},
+		{1, 1},
+		{2, 3},
+		{3, 5},
+	}
+	for _, tt := range tests {
+		t.Run(
+			fmt.Sprintf("max length %d", tt.maxLength), func(t *testing.T) {
+				count := 0
+				it := g.SimplePaths(0, 3, tt.maxLength)
+				for it.HasNext() {
+					path, _ := it.Next()
+					count++
+					if path[0] != 0 || path[len(path)-1] != 3 {
+						t.Errorf("Unexpected endpoints in %v", path)
+					}
+					if tt.maxLength >= 0 && len(path)-1 > tt.maxLength {
+						t.Errorf("Path %v is longer than %d", path, tt.maxLength)
+					}
+				}
+				if count != tt.expected {
+					t.Errorf("Expected %d paths, got %d", tt.expected, count)
+				}
+
+				it.Reset()
+				if tt.expected > 0 && !it.HasNext() {
+					t.Error("Expected Reset to restart the enumeration")
+				}
+			},
+		)
+	}
+
+	if it := g.SimplePaths(0, 0, -1); it.HasNext() {
+		t.Error("Expected no paths from a node to itself")
+	}
+	if it := g.SimplePaths(0, 42, -1); it.HasNext() {
+		t.Error("Expected no paths to a missing node")
+	}
+
+	frozen := g.Freeze().SimplePaths(0, 3, -1)
+	count := 0
+	for frozen.HasNext() {
+		frozen.Next()
+		count++
+	}
+	if count != 5 {
+		t.Errorf("Expected 5 paths on the frozen graph, got %d", count)
+	}
+}
@@ -0,0 +1,194 @@
+package graph
+
+import "sort"
+
+// edgesByID returns every edge of the graph once, in its from->to direction, ordered by ID.
+func (g *Graph[T]) edgesByID() []*edge[T] {
+	edges := make([]*edge[T], 0, len(g.edges))
+	for _, e := range g.edges {
+		edges = append(edges, e)
+	}
+	sort.Slice(
+		edges, func(i, j int) bool {
+			return edges[i].id < edges[j].id
+		},
+	)
+	return edges
+}
+
+// insertEdge adds a copy of e, keeping its ID and attributes.
+// Edges must be inserted in ID order for parallel edges to keep their order.
+// It returns false and leaves the graph unchanged if a simple graph already connects the nodes.
+func (g *Graph[T]) insertEdge(e Edge[T]) bool {
+	next := g.nextID
+	g.nextID = e.ID - 1
+	id, added := g.AddEdgeWithID(e.From, e.To, e.Weight)
+	if added && e.Attrs.Len() > 0 {
+		g.setEdgeAttrs(g.edges[id], e.Attrs)
+	}
+	if !added || next > g.nextID {
+		g.nextID = next
+	}
+	return added
+}
+
+// emptyCopy returns a graph of the same kind with the given nodes and their attributes but no edges.
+func (g *Graph[T]) emptyCopy(keep func(T) bool) *Graph[T] {
+	c := New[T](g.directed)
+	c.multi = g.multi
+	c.nextID = g.nextID
+	for value, n := range g.nodes {
+		if keep == nil || keep(value) {
+			c.AddNode(value)
+			c.nodes[value].attrs = n.attrs
+		}
+	}
+	return c
+}
+
+// Clone returns a deep copy of the graph. Edge IDs, weights and attributes are preserved;
+// the attribute values themselves are copied shallowly.
+func (g *Graph[T]) Clone() *Graph[T] {
+	return g.EdgeSubgraph(nil)
+}
+
+// Transpose returns a copy of the graph with the direction of every edge reversed.
+// Edge IDs, weights and attributes are preserved. For undirected graphs it is the same as Clone.
+func (g *Graph[T]) Transpose() *Graph[T] {
+	t := g.emptyCopy(nil)
+	for _, e := range g.edgesByID() {
+		view := e.view()
+		if g.directed {
+			view.From, view.To = view.To, view.From
+		}
+		t.insertEdge(view)
+	}
+	return t
+}
+
+// InducedSubgraph returns the subgraph made of the given nodes and all edges between them.
+// Values that are not in the graph are ignored. Edge IDs, weights and attributes are preserved.
+func (g *Graph[T]) InducedSubgraph(nodes []T) *Graph[T] {
+	keep := make(map[T]bool, len(nodes))
+	for _, v := range nodes {
+		keep[v] = true
+	}
+
+	sub := g.emptyCopy(
+		func(value T) bool {
+			return keep[value]
+		},
+	)
+	for _, e := range g.edgesByID() {
+		if keep[e.from.value] && keep[e.to.value] {
+			sub.insertEdge(e.view())
+		}
+	}
+	return sub
+}
+
+// EdgeSubgraph returns the subgraph made of the edges for which keep returns true and their endpoints.
+// A nil keep function keeps every edge and node. Edge IDs, weights and attributes are preserved.
+func (g *Graph[T]) EdgeSubgraph(keep func(Edge[T]) bool) *Graph[T] {
+	if keep == nil {
+		sub := g.emptyCopy(nil)
+		for _, e := range g.edgesByID() {
+			sub.insertEdge(e.view())
+		}
+		return sub
+	}
+
+	sub := g.emptyCopy(
+		func(T) bool {
+			return false
+		},
+	)
+	for _, e := range g.edgesByID() {
+		view := e.view()
+		if !keep(view) {
+			continue
+		}
+		for _, v := range []*node[T]{e.from, e.to} {
+			if sub.AddNode(v.value) {
+				sub.nodes[v.value].attrs = v.attrs
+			}
+		}
+		sub.insertEdge(view)
+	}
+	return sub
+}
+
+// Union returns a graph with the nodes and edges of both graphs.
+// Nodes and edges of g take precedence: edges of other between a pair of nodes are only added
+// if g has no edge between them, and then receive new IDs. Node attributes of other are used for nodes
+// missing from g. The result is a multigraph if either graph is one.
+// It returns ErrNilGraph if other is nil and ErrDirectionMismatch if only one of the graphs is directed.
+func (g *Graph[T]) Union(other *Graph[T]) (*Graph[T], error) {
+	if other == nil {
+		return nil, ErrNilGraph
+	}
+	if g.directed != other.directed {
+		return nil, ErrDirectionMismatch
+	}
+
+	u := g.Clone()
+	u.multi = g.multi || other.multi
+	for value, n := range other.nodes {
+		if u.AddNode(value) {
+			u.nodes[value].attrs = n.attrs
+		}
+	}
+	for _, e := range other.edgesByID() {
+		if g.HasEdge(e.from.value, e.to.value) {
+			continue
+		}
+		id, added := u.AddEdgeWithID(e.from.value, e.to.value, e.weight)
+		if added && e.attrs.Len() > 0 {
+			u.setEdgeAttrs(u.edges[id], e.attrs)
+		}
+	}
+	return u, nil
+}
+
+// Intersection returns a graph with the nodes present in both graphs and the edges of g
+// whose endpoints are also connected in other. Weights, IDs and attributes are taken from g.
+// It returns ErrNilGraph if other is nil and ErrDirectionMismatch if only one of the graphs is directed.
+func (g *Graph[T]) Intersection(other *Graph[T]) (*Graph[T], error) {
+	if other == nil {
+		return nil, ErrNilGraph
+	}
+	if g.directed != other.directed {
+		return nil, ErrDirectionMismatch
+	}
+
+	i := g.emptyCopy(other.HasNode)
+	for _, e := range g.edgesByID() {
+		if other.HasEdge(e.from.value, e.to.value) {
+			i.insertEdge(e.view())
+		}
+	}
+	return i, nil
+}
+
+// Complement returns a simple graph with the same nodes in which two distinct nodes are
+// connected exactly when they are not connected in g. The new edges have weight 1.
+func (g *Graph[T]) Complement() *Graph[T] {
+	c := New[T](g.directed)
+	values := g.Nodes()
+	for _, v := range values {
+		c.AddNode(v)
+		c.nodes[v].attrs = g.nodes[v].attrs
+	}
+
+	for i, from := range values {
+		for j, to := range values {
+			if i == j || (!g.directed && j < i) {
+				continue
+			}
+			if !g.HasEdge(from, to) {
+				c.AddEdge(from, to, 1)
+			}
+		}
+	}
+	return c
+}
@@ -0,0 +1,274 @@
+package graph
+
+import "testing"
+
+func TestClone(t *testing.T) {
+	label := NewAttr[string]("label")
+	rank := NewAttr[int]("rank")
+	g := NewMultigraph[string](true)
+	g.AddEdge("a", "b", 1)
+	id, _ := g.AddEdgeWith("a", "b", 2, label.With("second"))
+	g.SetNodeAttrs("a", rank.With(42))
+	g.AddNode("c")
+
+	c := g.Clone()
+	assertSameGraph(t, g, c)
+	if !c.IsMultigraph() {
+		t.Error("Expected clone to be a multigraph")
+	}
+	if e, ok := c.EdgeByID(id); !ok || e.Weight != 2 {
+		t.Errorf("Expected edge %d to keep its weight, got %+v", id, e)
+	} else if value, _ := label.Get(e.Attrs); value != "second" {
+		t.Errorf("Expected edge %d to keep its label, got %q", id, value)
+	}
+	if value, _ := rank.Get(c.NodeAttrs("a")); value != 42 {
+		t.Errorf("Expected node rank 42, got %v", value)
+	}
+
+	c.SetNodeAttrs("a", rank.With(7))
+	if value, _ := rank.Get(g.NodeAttrs("a")); value != 42 {
+		t.Errorf("Changing attributes of the clone should not affect the original, got %v", value)
+	}
+
+	c.AddEdge("b", "c", 1)
+	c.RemoveNode("a")
+	if g.HasEdge("b", "c") || !g.HasNode("a") {
+		t.Error("Changes to the clone should not affect the original")
+	}
+	if next, _ := c.AddEdgeWithID("c", "b", 1); next <= id {
+		t.Errorf("Expected clone to hand out fresh IDs, got %d", next)
+	}
+}
+
+func TestGraph_InsertEdge(t *testing.T) {
+	label := NewAttr[string]("label")
+	g := New[int](false)
+	g.AddEdge(1, 2, 1)
+
+	duplicate := Edge[int]{ID: 7, From: 2, To: 1, Weight: 5, Attrs: Attrs{}.with([]AttrValue{label.With("x")})}
+	if g.insertEdge(duplicate) {
+		t.Error("Expected the duplicate edge to be rejected")
+	}
+	if w, _ := g.GetEdgeWeight(1, 2); w != 1 || g.EdgeAttrs(1, 2).Len() != 0 {
+		t.Errorf("Expected the existing edge to be unchanged, got weight %v", w)
+	}
+	if id, _ := g.AddEdgeWithID(2, 3, 1); id != 2 {
+		t.Errorf("Expected the next ID to be 2, got %d", id)
+	}
+
+	if !g.insertEdge(Edge[int]{ID: 10, From: 3, To: 4, Weight: 2, Attrs: duplicate.Attrs}) {
+		t.Fatal("Expected the new edge to be inserted")
+	}
+	if e, _ := g.GetEdge(3, 4); e.ID != 10 || e.Attrs.Len() != 1 {
+		t.Errorf("Expected edge 10 with its attributes, got %+v", e)
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(1, 2, 3)
+	g.AddEdge(2, 3, 4)
+	g.AddEdge(3, 3, 5)
+	label := NewAttr[string]("label")
+	g.SetEdgeAttrs(1, 2, label.With("x"))
+
+	tr := g.Transpose()
+	if tr.HasEdge(1, 2) || !tr.HasEdge(2, 1) || !tr.HasEdge(3, 2) || !tr.HasEdge(3, 3) {
+		t.Errorf("Unexpected transposed edges %v", tr.Edges())
+	}
+	if w, _ := tr.GetEdgeWeight(3, 2); w != 4 {
+		t.Errorf("Expected weight 4, got %v", w)
+	}
+	if value, _ := label.Get(tr.EdgeAttrs(2, 1)); value != "x" {
+		t.Errorf("Expected edge label to be kept, got %v", value)
+	}
+	if e1, _ := g.GetEdge(1, 2); tr.edges[e1.ID].from.value != 2 {
+		t.Error("Expected edge IDs to be preserved")
+	}
+	if len(tr.Neighbors(1)) != 0 {
+		t.Errorf("Expected node 1 to have no outgoing edges, got %v", tr.Neighbors(1))
+	}
+
+	u := New[int](false)
+	u.AddEdge(1, 2, 1)
+	assertSameGraph(t, u, u.Transpose())
+}
+
+func TestInducedSubgraph(t *testing.T) {
+	for _, directed := range []bool{false, true} {
+		g := bowtieGraph(directed)
+		sub := g.InducedSubgraph([]int{1, 2, 3, 4, 42})
+
+		if len(sub.Nodes()) != 4 || sub.HasNode(42) {
+			t.Errorf("directed=%v: expected nodes 1-4, got %v", directed, sub.Nodes())
+		}
+		if len(sub.Edges()) != 4 {
+			t.Errorf("directed=%v: expected 4 edges, got %v", directed, sub.Edges())
+		}
+		if !sub.HasEdge(3, 4) || sub.HasNode(5) {
+			t.Errorf("directed=%v: unexpected subgraph %v", directed, sub.Edges())
+		}
+		if sub.IsMultigraph() != g.IsMultigraph() || sub.directed != directed {
+			t.Errorf("directed=%v: expected the kind of graph to be preserved", directed)
+		}
+	}
+}
+
+func TestEdgeSubgraph(t *testing.T) {
+	g := treeGraph()
+	sub := g.EdgeSubgraph(
+		func(e Edge[int]) bool {
+			return e.Weight < 5
+		},
+	)
+
+	// Weights are from+to, so only 1-2 and 1-3 are kept
+	if len(sub.Edges()) != 2 || !sub.HasEdge(2, 1) || !sub.HasEdge(1, 3) {
+		t.Errorf("Expected edges 1-2 and 1-3, got %v", sub.Edges())
+	}
+	if len(sub.Nodes()) != 3 {
+		t.Errorf("Expected only the endpoints of kept edges, got %v", sub.Nodes())
+	}
+
+	assertSameGraph(t, g, g.EdgeSubgraph(nil))
+}
+
+func TestUnion(t *testing.T) {
+	g := New[int](false)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+
+	other := New[int](false)
+	other.AddEdge(2, 1, 5)
+	other.AddEdge(3, 4, 2)
+	label := NewAttr[string]("label")
+	other.AddNodeWith(9, label.With("nine"))
+
+	u, err := g.Union(other)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(u.Nodes()) != 5 || len(u.Edges()) != 3 {
+		t.Errorf("Expected 5 nodes and 3 edges, got %v and %v", u.Nodes(), u.Edges())
+	}
+	if w, _ := u.GetEdgeWeight(1, 2); w != 1 {
+		t.Errorf("Expected the weight of the receiver to win, got %v", w)
+	}
+	if w, _ := u.GetEdgeWeight(4, 3); w != 2 {
+		t.Errorf("Expected edge 3-4 with weight 2, got %v", w)
+	}
+	if value, _ := label.Get(u.NodeAttrs(9)); value != "nine" {
+		t.Errorf("Expected node label of other, got %v", value)
+	}
+
+	if _, err := g.Union(New[int](true)); err != ErrDirectionMismatch {
+		t.Errorf("Expected ErrDirectionMismatch, got %v", err)
+	}
+	if _, err := g.Union(nil); err != ErrNilGraph {
+		t.Errorf("Expected ErrNilGraph, got %v", err)
+	}
+}
+
+func TestUnion_Multigraph(t *testing.T) {
+	label := NewAttr[string]("label")
+	for _, directed := range []bool{false, true} {
+		g := New[int](directed)
+		g.AddEdge(1, 2, 1)
+
+		other := NewMultigraph[int](directed)
+		other.AddEdgeWith(1, 2, 5, label.With("ignored"))
+		other.AddEdgeWith(2, 3, 1, label.With("first"))
+		other.AddEdgeWith(2, 3, 2, label.With("second"))
+
+		u, err := g.Union(other)
+		if err != nil {
+			t.Fatalf("directed=%v: unexpected error: %v", directed, err)
+		}
+		if !u.IsMultigraph() {
+			t.Errorf("directed=%v: expected a multigraph", directed)
+		}
+		if edges := u.EdgesBetween(1, 2); len(edges) != 1 || edges[0].Weight != 1 {
+			t.Errorf("directed=%v: expected only the edge of the receiver between 1 and 2, got %+v", directed, edges)
+		}
+
+		edges := u.EdgesBetween(2, 3)
+		if len(edges) != 2 {
+			t.Fatalf("directed=%v: expected both parallel edges of other, got %+v", directed, edges)
+		}
+		for i, expected := range []string{"first", "second"} {
+			if value, _ := label.Get(edges[i].Attrs); value != expected {
+				t.Errorf("directed=%v: expected edge label %q, got %q", directed, expected, value)
+			}
+		}
+		if g.IsMultigraph() {
+			t.Errorf("directed=%v: Union should not change the receiver", directed)
+		}
+	}
+}
+
+func TestIntersection(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 1, 7)
+	g.AddNode(4)
+
+	other := New[int](true)
+	other.AddEdge(2, 1, 1)
+	other.AddEdge(3, 1, 2)
+	other.AddNode(2)
+
+	i, err := g.Intersection(other)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(i.Nodes()) != 3 || i.HasNode(4) {
+		t.Errorf("Expected nodes 1, 2 and 3, got %v", i.Nodes())
+	}
+	if edges := i.Edges(); len(edges) != 1 || edges[0] != [2]int{3, 1} {
+		t.Errorf("Expected the single edge 3->1, got %v", edges)
+	}
+	if w, _ := i.GetEdgeWeight(3, 1); w != 7 {
+		t.Errorf("Expected the weight of the receiver, got %v", w)
+	}
+
+	if _, err := g.Intersection(nil); err != ErrNilGraph {
+		t.Errorf("Expected ErrNilGraph, got %v", err)
+	}
+	if _, err := New[int](false).Intersection(other); err != ErrDirectionMismatch {
+		t.Errorf("Expected ErrDirectionMismatch, got %v", err)
+	}
+}
+
+func TestComplement(t *testing.T) {
+	tests := []struct {
+		name     string
+		directed bool
+		expected int
+	}{
+		{"undirected", false, 6 - 2},
+		{"directed", true, 12 - 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				g := New[int](tt.directed)
+				g.AddEdge(1, 2, 3)
+				g.AddEdge(3, 3, 1)
+				g.AddEdge(3, 4, 1)
+
+				c := g.Complement()
+				if len(c.Nodes()) != 4 || len(c.Edges()) != tt.expected {
+					t.Errorf("Expected 4 nodes and %d edges, got %v", tt.expected, c.Edges())
+				}
+				if c.HasEdge(1, 2) || c.HasEdge(3, 3) || !c.HasEdge(1, 3) {
+					t.Errorf("Unexpected complement %v", c.Edges())
+				}
+				if c.HasEdge(2, 1) != tt.directed {
+					t.Errorf("Expected edge 2->1 only in the directed complement")
+				}
+			},
+		)
+	}
+}
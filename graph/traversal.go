@@ -0,0 +1,219 @@
+package graph
+
+import "sort"
+
+// TraversalOption configures breadth-first and depth-first traversals.
+type TraversalOption[T comparable] func(*traversal[T])
+
+type traversal[T comparable] struct {
+	maxDepth int // Negative means unlimited
+	filter   func(Edge[T]) bool
+	less     func(a, b T) bool
+	all      bool
+}
+
+// WithMaxDepth limits a traversal to nodes at most depth edges away from where it started.
+func WithMaxDepth[T comparable](depth int) TraversalOption[T] {
+	return func(tr *traversal[T]) {
+		tr.maxDepth = depth
+	}
+}
+
+// WithEdgeFilter makes a traversal follow only the edges for which keep returns true.
+// In a multigraph a neighbor is reachable if any of the parallel edges is kept.
+func WithEdgeFilter[T comparable](keep func(Edge[T]) bool) TraversalOption[T] {
+	return func(tr *traversal[T]) {
+		tr.filter = keep
+	}
+}
+
+// WithNodeOrder makes a traversal deterministic by visiting neighbors,
+// and the roots of further components, in the order defined by less.
+func WithNodeOrder[T comparable](less func(a, b T) bool) TraversalOption[T] {
+	return func(tr *traversal[T]) {
+		tr.less = less
+	}
+}
+
+// WithAllComponents continues a traversal from the remaining unvisited nodes
+// once the component of the start node is exhausted, so every node is visited.
+func WithAllComponents[T comparable]() TraversalOption[T] {
+	return func(tr *traversal[T]) {
+		tr.all = true
+	}
+}
+
+func newTraversal[T comparable](opts []TraversalOption[T]) *traversal[T] {
+	tr := &traversal[T]{maxDepth: -1}
+	for _, opt := range opts {
+		opt(tr)
+	}
+	return tr
+}
+
+// canDescend reports whether nodes at the given depth may be visited.
+func (tr *traversal[T]) canDescend(depth int) bool {
+	return tr.maxDepth < 0 || depth <= tr.maxDepth
+}
+
+// sortNodes orders values with the configured less function, if any.
+func (tr *traversal[T]) sortNodes(values []T) {
+	if tr.less != nil {
+		sort.Slice(
+			values, func(i, j int) bool {
+				return tr.less(values[i], values[j])
+			},
+		)
+	}
+}
+
+// neighbors returns the nodes reachable from value through edges accepted by the traversal.
+func (g *Graph[T]) neighbors(tr *traversal[T], value T) []T {
+	n, exists := g.nodes[value]
+	if !exists {
+		return nil
+	}
+
+	neighbors := make([]T, 0, len(n.edges))
+	for neighbor, head := range n.edges {
+		if tr.filter == nil {
+			neighbors = append(neighbors, neighbor)
+			continue
+		}
+		for e := head; e != nil; e = e.next {
+			if tr.filter(e.view()) {
+				neighbors = append(neighbors, neighbor)
+				break
+			}
+		}
+	}
+	tr.sortNodes(neighbors)
+	return neighbors
+}
+
+// roots returns start followed, when all components are requested, by every other node.
+func (g *Graph[T]) roots(tr *traversal[T], start T) []T {
+	if !g.HasNode(start) {
+		return nil
+	}
+	if !tr.all {
+		return []T{start}
+	}
+
+	rest := make([]T, 0, len(g.nodes))
+	for value := range g.nodes {
+		if value != start {
+			rest = append(rest, value)
+		}
+	}
+	tr.sortNodes(rest)
+	return append([]T{start}, rest...)
+}
+
+// BFS performs a breadth-first traversal starting from the given node.
+// The visit function receives each node with its distance in edges from the root of its component,
+// and the traversal stops early if it returns false.
+func (g *Graph[T]) BFS(start T, visit func(value T, depth int) bool, opts ...TraversalOption[T]) {
+	tr := newTraversal(opts)
+	visited := make(map[T]bool)
+
+	for _, root := range g.roots(tr, start) {
+		if visited[root] {
+			continue
+		}
+		visited[root] = true
+		queue := []T{root}
+		depths := []int{0}
+
+		for len(queue) > 0 {
+			current, depth := queue[0], depths[0]
+			queue, depths = queue[1:], depths[1:]
+
+			if !visit(current, depth) {
+				return
+			}
+			if !tr.canDescend(depth + 1) {
+				continue
+			}
+			for _, neighbor := range g.neighbors(tr, current) {
+				if !visited[neighbor] {
+					visited[neighbor] = true
+					queue = append(queue, neighbor)
+					depths = append(depths, depth+1)
+				}
+			}
+		}
+	}
+}
+
+// DFS performs a depth-first traversal starting from the given node, visiting nodes in pre-order.
+// The visit function receives each node with its depth in the search tree,
+// and the traversal stops early if it returns false.
+func (g *Graph[T]) DFS(start T, visit func(value T, depth int) bool, opts ...TraversalOption[T]) {
+	g.dfs(start, visit, nil, opts)
+}
+
+// DFSPostOrder performs a depth-first traversal starting from the given node,
+// visiting each node after all of its descendants.
+func (g *Graph[T]) DFSPostOrder(start T, visit func(value T, depth int) bool, opts ...TraversalOption[T]) {
+	g.dfs(start, nil, visit, opts)
+}
+
+// dfsFrame is an entry of the explicit depth-first search stack.
+type dfsFrame[T comparable] struct {
+	value     T
+	depth     int
+	neighbors []T
+	next      int
+}
+
+// dfs runs an iterative depth-first search calling pre when a node is entered
+// and post when it is left. Either callback may be nil.
+func (g *Graph[T]) dfs(start T, pre, post func(T, int) bool, opts []TraversalOption[T]) {
+	tr := newTraversal(opts)
+	visited := make(map[T]bool)
+
+	enter := func(value T, depth int) (dfsFrame[T], bool) {
+		visited[value] = true
+		if pre != nil && !pre(value, depth) {
+			return dfsFrame[T]{}, false
+		}
+		frame := dfsFrame[T]{value: value, depth: depth}
+		if tr.canDescend(depth + 1) {
+			frame.neighbors = g.neighbors(tr, value)
+		}
+		return frame, true
+	}
+
+	for _, root := range g.roots(tr, start) {
+		if visited[root] {
+			continue
+		}
+		frame, ok := enter(root, 0)
+		if !ok {
+			return
+		}
+		stack := []dfsFrame[T]{frame}
+
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next < len(top.neighbors) {
+				neighbor := top.neighbors[top.next]
+				top.next++
+				if !visited[neighbor] {
+					frame, ok := enter(neighbor, top.depth+1)
+					if !ok {
+						return
+					}
+					stack = append(stack, frame)
+				}
+				continue
+			}
+
+			stack = stack[:len(stack)-1]
+			if post != nil && !post(top.value, top.depth) {
+				return
+			}
+		}
+	}
+}
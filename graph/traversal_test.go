@@ -0,0 +1,130 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/idsulik/go-collections/v3/internal/slices"
+)
+
+func lessInt(a, b int) bool {
+	return a < b
+}
+
+// treeGraph builds the undirected tree 1-{2,3}, 2-{4,5}, 3-{6} plus the isolated component 7-8.
+func treeGraph() *Graph[int] {
+	g := New[int](false)
+	for _, e := range [][2]int{{1, 2}, {1, 3}, {2, 4}, {2, 5}, {3, 6}, {7, 8}} {
+		g.AddEdge(e[0], e[1], float64(e[0]+e[1]))
+	}
+	return g
+}
+
+func collect(
+	traverse func(start int, visit func(int, int) bool, opts ...TraversalOption[int]),
+	start int,
+	opts ...TraversalOption[int],
+) ([]int, []int) {
+	var order, depths []int
+	traverse(
+		start, func(value, depth int) bool {
+			order = append(order, value)
+			depths = append(depths, depth)
+			return true
+		}, opts...,
+	)
+	return order, depths
+}
+
+func TestTraversalOrders(t *testing.T) {
+	g := treeGraph()
+	order := WithNodeOrder(lessInt)
+
+	tests := []struct {
+		name     string
+		run      func(start int, visit func(int, int) bool, opts ...TraversalOption[int])
+		opts     []TraversalOption[int]
+		expected []int
+		depths   []int
+	}{
+		{"BFS", g.BFS, nil, []int{1, 2, 3, 4, 5, 6}, []int{0, 1, 1, 2, 2, 2}},
+		{"DFS pre-order", g.DFS, nil, []int{1, 2, 4, 5, 3, 6}, []int{0, 1, 2, 2, 1, 2}},
+		{"DFS post-order", g.DFSPostOrder, nil, []int{4, 5, 2, 6, 3, 1}, []int{2, 2, 1, 2, 1, 0}},
+		{
+			"BFS all components", g.BFS, []TraversalOption[int]{WithAllComponents[int]()},
+			[]int{1, 2, 3, 4, 5, 6, 7, 8}, []int{0, 1, 1, 2, 2, 2, 0, 1},
+		},
+		{
+			"DFS all components", g.DFS, []TraversalOption[int]{WithAllComponents[int]()},
+			[]int{1, 2, 4, 5, 3, 6, 7, 8}, []int{0, 1, 2, 2, 1, 2, 0, 1},
+		},
+		{"BFS max depth", g.BFS, []TraversalOption[int]{WithMaxDepth[int](1)}, []int{1, 2, 3}, []int{0, 1, 1}},
+		{"DFS max depth", g.DFSPostOrder, []TraversalOption[int]{WithMaxDepth[int](1)}, []int{2, 3, 1}, []int{1, 1, 0}},
+		{
+			"edge filter", g.DFS,
+			[]TraversalOption[int]{WithEdgeFilter(func(e Edge[int]) bool { return e.Weight < 7 })},
+			[]int{1, 2, 4, 3}, []int{0, 1, 2, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				got, depths := collect(tt.run, 1, append(tt.opts, order)...)
+				if !slices.Equal(got, tt.expected) {
+					t.Errorf("Expected order %v, got %v", tt.expected, got)
+				}
+				if !slices.Equal(depths, tt.depths) {
+					t.Errorf("Expected depths %v, got %v", tt.depths, depths)
+				}
+			},
+		)
+	}
+}
+
+func TestTraversal_EarlyStop(t *testing.T) {
+	g := treeGraph()
+	for name, run := range map[string]func(int, func(int, int) bool, ...TraversalOption[int]){
+		"BFS":          g.BFS,
+		"DFS":          g.DFS,
+		"DFSPostOrder": g.DFSPostOrder,
+	} {
+		count := 0
+		run(
+			1, func(int, int) bool {
+				count++
+				return count < 2
+			},
+		)
+		if count != 2 {
+			t.Errorf("%s: expected traversal to stop after 2 nodes, visited %d", name, count)
+		}
+	}
+}
+
+func TestTraversal_MissingStart(t *testing.T) {
+	g := treeGraph()
+	visited, _ := collect(g.DFS, 42, WithAllComponents[int]())
+	if len(visited) != 0 {
+		t.Errorf("Expected no nodes to be visited, got %v", visited)
+	}
+}
+
+func TestTraversal_DeepGraph(t *testing.T) {
+	// A long path must not overflow the stack
+	g := New[int](true)
+	const n = 100000
+	for i := 0; i < n; i++ {
+		g.AddEdge(i, i+1, 1)
+	}
+
+	count := 0
+	g.DFSPostOrder(
+		0, func(int, int) bool {
+			count++
+			return true
+		},
+	)
+	if count != n+1 {
+		t.Errorf("Expected %d nodes, got %d", n+1, count)
+	}
+}
@@ -0,0 +1,142 @@
+package hyperloglog
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/idsulik/go-collections/v3/bloomfilter"
+	"github.com/idsulik/go-collections/v3/internal/binenc"
+)
+
+// The binary encoding is an 8-byte header followed by the sketch in its current representation:
+//
+//	magic "HL" | version | precision | format | 3 reserved bytes
+//
+// The dense format (1) is followed by the 2^precision registers, one byte each. The sparse format (0)
+// is followed by the number of entries and the sorted encoded hashes, all little-endian uint32.
+const (
+	encodingVersion = 1
+	headerSize      = 8
+
+	formatSparse = 0
+	formatDense  = 1
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler. It does not modify the sketch.
+// The hasher is not encoded: the decoding side must use the same one.
+func (h *HyperLogLog[T]) MarshalBinary() ([]byte, error) {
+	header := []byte{'H', 'L', encodingVersion, h.precision, formatDense, 0, 0, 0}
+	if h.registers != nil {
+		return append(header, h.registers...), nil
+	}
+
+	// Encode the representation the sketch would have once its buffered hashes are merged
+	sparse := h.sparseEntries()
+	if h.tooDense(sparse) {
+		return append(header, h.denseRegisters(sparse)...), nil
+	}
+	header[4] = formatSparse
+	data := make([]byte, headerSize+4+4*len(sparse))
+	copy(data, header)
+	binary.LittleEndian.PutUint32(data[headerSize:], uint32(len(sparse)))
+	for i, k := range sparse {
+		binary.LittleEndian.PutUint32(data[headerSize+4+4*i:], k)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It replaces the contents of the sketch and keeps its hasher, using the default one if none is set.
+func (h *HyperLogLog[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize {
+		return fmt.Errorf("%w: data too short", ErrInvalidEncoding)
+	}
+	if err := checkHeader(data); err != nil {
+		return err
+	}
+	decoded := &HyperLogLog[T]{precision: data[3], hash: h.hash}
+	if decoded.hash == nil {
+		decoded.hash = bloomfilter.DefaultHasher[T]()
+	}
+	body := data[headerSize:]
+
+	if data[4] == formatDense {
+		if len(body) != 1<<decoded.precision {
+			return fmt.Errorf("%w: expected %d registers, got %d", ErrInvalidEncoding, 1<<decoded.precision, len(body))
+		}
+		maxRho := uint8(64 - decoded.precision + 1)
+		for _, rho := range body {
+			if rho > maxRho {
+				return fmt.Errorf("%w: register value %d out of range", ErrInvalidEncoding, rho)
+			}
+		}
+		decoded.registers = append([]uint8(nil), body...)
+		*h = *decoded
+		return nil
+	}
+
+	if len(body) < 4 || uint64(len(body)-4) != 4*uint64(binary.LittleEndian.Uint32(body)) {
+		return fmt.Errorf("%w: sparse length mismatch", ErrInvalidEncoding)
+	}
+	decoded.sparse = make([]uint32, (len(body)-4)/4)
+	for i := range decoded.sparse {
+		k := binary.LittleEndian.Uint32(body[4+4*i:])
+		if !decoded.validEncoding(k) || i > 0 && sparseIndex(decoded.sparse[i-1]) >= sparseIndex(k) {
+			return fmt.Errorf("%w: invalid sparse entry %#x", ErrInvalidEncoding, k)
+		}
+		decoded.sparse[i] = k
+	}
+	*h = *decoded
+	return nil
+}
+
+// validEncoding reports whether k could have been produced by encodeHash.
+func (h *HyperLogLog[T]) validEncoding(k uint32) bool {
+	if k&1 == 0 {
+		return k>>(sparsePrecision+1) == 0 && sparseIndex(k)&(1<<(sparsePrecision-h.precision)-1) != 0
+	}
+	rho := k >> 1 & 63
+	return rho >= 1 && rho <= 64-sparsePrecision+1 && sparseIndex(k)&(1<<(sparsePrecision-h.precision)-1) == 0
+}
+
+// WriteTo implements io.WriterTo, writing the binary encoding of the sketch to w.
+func (h *HyperLogLog[T]) WriteTo(w io.Writer) (int64, error) {
+	return binenc.WriteTo(w, h)
+}
+
+// ReadFrom implements io.ReaderFrom, reading a sketch written by WriteTo from r.
+// It reads exactly the bytes of one encoded sketch.
+func (h *HyperLogLog[T]) ReadFrom(r io.Reader) (int64, error) {
+	return binenc.ReadFrom(
+		r, h, headerSize, func(data []byte) (int64, error) {
+			if err := checkHeader(data); err != nil {
+				return 0, err
+			}
+			if data[4] == formatDense {
+				return headerSize + 1<<data[3], nil
+			}
+			// The sparse format has the number of entries in front of them
+			if len(data) < headerSize+4 {
+				return headerSize + 4, nil
+			}
+			return headerSize + 4 + 4*int64(binary.LittleEndian.Uint32(data[headerSize:])), nil
+		},
+	)
+}
+
+func checkHeader(data []byte) error {
+	if data[0] != 'H' || data[1] != 'L' {
+		return fmt.Errorf("%w: bad magic", ErrInvalidEncoding)
+	}
+	if data[2] != encodingVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, data[2])
+	}
+	if data[3] < MinPrecision || data[3] > MaxPrecision {
+		return fmt.Errorf("%w: precision %d out of range", ErrInvalidEncoding, data[3])
+	}
+	if data[4] != formatSparse && data[4] != formatDense {
+		return fmt.Errorf("%w: unknown format %d", ErrInvalidEncoding, data[4])
+	}
+	return nil
+}
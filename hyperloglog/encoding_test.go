@@ -0,0 +1,174 @@
+package hyperloglog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestHyperLogLog_MarshalBinary(t *testing.T) {
+	tests := []struct {
+		name   string
+		n      int
+		sparse bool
+	}{
+		{"Empty", 0, true},
+		{"Sparse", 300, true},
+		{"Dense", 50000, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				h, _ := New[int](12)
+				for i := 0; i < tt.n; i++ {
+					h.Add(i)
+				}
+
+				data, err := h.MarshalBinary()
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				var decoded HyperLogLog[int]
+				if err := decoded.UnmarshalBinary(data); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if (decoded.registers == nil) != tt.sparse {
+					t.Errorf("Expected sparse=%v after decoding", tt.sparse)
+				}
+				if decoded.Precision() != 12 || decoded.Count() != h.Count() {
+					t.Errorf("Expected count %d, got %d", h.Count(), decoded.Count())
+				}
+
+				// The decoded sketch keeps working with the default hasher
+				decoded.Add(-1)
+				h.Add(-1)
+				if decoded.Count() != h.Count() {
+					t.Errorf("Expected count %d after adding, got %d", h.Count(), decoded.Count())
+				}
+			},
+		)
+	}
+}
+
+func TestHyperLogLog_MarshalBinaryKeepsReceiver(t *testing.T) {
+	tests := []struct {
+		name      string
+		precision uint8
+		format    byte
+	}{
+		{"Buffered hashes stay sparse", 12, formatSparse},
+		{"Buffered hashes would switch to dense", 4, formatDense},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				h, _ := New[int](tt.precision)
+				for i := 0; i < 10; i++ {
+					h.Add(i)
+				}
+				if len(h.tmp) != 10 {
+					t.Fatalf("Expected 10 buffered hashes, got %d", len(h.tmp))
+				}
+
+				data, _ := h.MarshalBinary()
+				if data[4] != tt.format {
+					t.Errorf("Expected format %d, got %d", tt.format, data[4])
+				}
+				if len(h.tmp) != 10 || h.sparse != nil || h.registers != nil {
+					t.Error("MarshalBinary should not modify the sketch")
+				}
+
+				var decoded HyperLogLog[int]
+				if err := decoded.UnmarshalBinary(data); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if decoded.Count() != h.Count() {
+					t.Errorf("Expected count %d, got %d", h.Count(), decoded.Count())
+				}
+			},
+		)
+	}
+}
+
+func TestHyperLogLog_UnmarshalBinaryErrors(t *testing.T) {
+	sparse, _ := New[int](10)
+	sparse.Add(1)
+	sparse.Add(2)
+	validSparse, _ := sparse.MarshalBinary()
+
+	dense, _ := New[int](4)
+	for i := 0; i < 100; i++ {
+		dense.Add(i)
+	}
+	validDense, _ := dense.MarshalBinary()
+
+	corrupt := func(valid []byte, f func(data []byte)) []byte {
+		data := append([]byte(nil), valid...)
+		f(data)
+		return data
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"Empty", nil},
+		{"Bad magic", corrupt(validSparse, func(d []byte) { d[0] = 'X' })},
+		{"Unknown version", corrupt(validSparse, func(d []byte) { d[2] = 9 })},
+		{"Precision out of range", corrupt(validSparse, func(d []byte) { d[3] = MaxPrecision + 1 })},
+		{"Unknown format", corrupt(validSparse, func(d []byte) { d[4] = 7 })},
+		{"Sparse length mismatch", validSparse[:len(validSparse)-1]},
+		{"Sparse entries out of order", corrupt(validSparse, func(d []byte) { copy(d[12:16], d[16:20]) })},
+		{"Invalid sparse entry", corrupt(validSparse, func(d []byte) { d[12], d[13], d[14], d[15] = 1, 0, 0, 0 })},
+		{"Dense length mismatch", validDense[:len(validDense)-1]},
+		{"Register out of range", corrupt(validDense, func(d []byte) { d[headerSize] = 62 })},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				var decoded HyperLogLog[int]
+				if err := decoded.UnmarshalBinary(tt.data); !errors.Is(err, ErrInvalidEncoding) {
+					t.Errorf("Expected ErrInvalidEncoding, got %v", err)
+				}
+			},
+		)
+	}
+}
+
+func TestHyperLogLog_WriteToReadFrom(t *testing.T) {
+	for _, n := range []int{10, 10000} {
+		h, _ := New[string](10)
+		for i := 0; i < n; i++ {
+			h.Add(fmt.Sprintf("item-%d", i))
+		}
+
+		var buf bytes.Buffer
+		written, err := h.WriteTo(&buf)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		buf.WriteString("trailer")
+
+		decoded, _ := New[string](4)
+		read, err := decoded.ReadFrom(&buf)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if read != written || buf.String() != "trailer" {
+			t.Errorf("Expected to read %d bytes, read %d and left %q", written, read, buf.String())
+		}
+		if decoded.Count() != h.Count() {
+			t.Errorf("Expected count %d, got %d", h.Count(), decoded.Count())
+		}
+
+		data, _ := h.MarshalBinary()
+		if _, err := decoded.ReadFrom(bytes.NewReader(data[:len(data)-1])); err != io.ErrUnexpectedEOF {
+			t.Errorf("Expected io.ErrUnexpectedEOF, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package hyperloglog
+
+import "errors"
+
+var (
+	// ErrInvalidPrecision is returned when creating a sketch with a precision outside [MinPrecision, MaxPrecision].
+	ErrInvalidPrecision = errors.New("hyperloglog: invalid precision")
+
+	// ErrNilSketch is returned when merging a nil sketch.
+	ErrNilSketch = errors.New("hyperloglog: nil sketch")
+
+	// ErrPrecisionMismatch is returned when merging sketches with different precisions.
+	ErrPrecisionMismatch = errors.New("hyperloglog: sketches have different precisions")
+
+	// ErrInvalidEncoding is returned when decoding data that is not a valid encoded sketch.
+	ErrInvalidEncoding = errors.New("hyperloglog: invalid encoding")
+)
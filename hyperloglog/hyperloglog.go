@@ -0,0 +1,378 @@
+// Package hyperloglog implements the HyperLogLog++ cardinality estimator (Heule et al., 2013), with
+// one deviation: dense estimates are corrected with the improved estimator of Ertl (2017) instead of
+// the empirical bias tables of HyperLogLog++. Both remove the small-range bias of raw HyperLogLog;
+// Ertl's estimator needs no tables or interpolation, so estimates differ slightly from other
+// HyperLogLog++ implementations for the same registers.
+package hyperloglog
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/idsulik/go-collections/v3/bloomfilter"
+)
+
+const (
+	// MinPrecision is the smallest supported precision.
+	MinPrecision = 4
+	// MaxPrecision is the largest supported precision.
+	MaxPrecision = 18
+
+	// sparsePrecision is the precision p' of the sparse representation
+	sparsePrecision = 25
+)
+
+// HyperLogLog estimates the number of distinct items added to it using HyperLogLog++
+// (Heule et al., 2013). A sketch with precision p uses 2^p registers and has a standard error of
+// about 1.04/sqrt(2^p): 0.81% for the default precision of 14, using 16 KiB.
+//
+// Small sketches use a sparse representation that stores only the touched registers at the higher
+// precision p' = 25, where counts are estimated by linear counting and are nearly exact. Once it would
+// use more memory than the registers, the sketch switches to the dense representation, whose
+// estimates are corrected for small-range bias with the improved estimator of Ertl (2017) rather than
+// the bias tables of HyperLogLog++.
+type HyperLogLog[T any] struct {
+	precision uint8
+	registers []uint8  // Dense registers, nil while sparse
+	sparse    []uint32 // Sorted encoded hashes, unique by sparse index
+	tmp       []uint32 // Unsorted encoded hashes not yet merged into sparse
+	hash      bloomfilter.Hasher[T]
+}
+
+// Option is a function that configures a HyperLogLog.
+type Option[T any] func(*HyperLogLog[T])
+
+// WithHasher sets the function used to hash items. Only the first half of the 128-bit hash is used.
+func WithHasher[T any](hasher bloomfilter.Hasher[T]) Option[T] {
+	return func(h *HyperLogLog[T]) {
+		h.hash = hasher
+	}
+}
+
+// WithEncoder hashes items from the bytes produced by enc.
+func WithEncoder[T any](enc bloomfilter.Encoder[T]) Option[T] {
+	return func(h *HyperLogLog[T]) {
+		h.hash = bloomfilter.EncoderHasher(enc)
+	}
+}
+
+// New creates an empty sketch with 2^precision registers.
+// It returns ErrInvalidPrecision if precision is outside [MinPrecision, MaxPrecision].
+func New[T any](precision uint8, opts ...Option[T]) (*HyperLogLog[T], error) {
+	if precision < MinPrecision || precision > MaxPrecision {
+		return nil, ErrInvalidPrecision
+	}
+	h := &HyperLogLog[T]{
+		precision: precision,
+		hash:      bloomfilter.DefaultHasher[T](),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h, nil
+}
+
+// Add inserts an item into the sketch.
+func (h *HyperLogLog[T]) Add(item T) {
+	x, _ := h.hash(item)
+	h.AddHash(x)
+}
+
+// AddHash inserts a precomputed 64-bit hash into the sketch.
+// Hashes must be uniformly distributed, and sketches that are merged must use the same hash function.
+func (h *HyperLogLog[T]) AddHash(x uint64) {
+	if h.registers != nil {
+		idx, rho := h.denseIndex(x)
+		if rho > h.registers[idx] {
+			h.registers[idx] = rho
+		}
+		return
+	}
+
+	h.tmp = append(h.tmp, h.encodeHash(x))
+	if len(h.tmp) >= h.tmpLimit() {
+		h.mergeTmp()
+	}
+}
+
+// Count returns the estimated number of distinct items added to the sketch.
+// It does not modify the sketch, so concurrent calls are safe as long as no items are added.
+func (h *HyperLogLog[T]) Count() uint64 {
+	registers := h.registers
+	if registers == nil {
+		entries := h.sparseEntries()
+		if !h.tooDense(entries) {
+			m := float64(uint64(1) << sparsePrecision)
+			return uint64(math.Round(linearCounting(m, m-float64(len(entries)))))
+		}
+		registers = h.denseRegisters(entries)
+	}
+	return uint64(math.Round(h.estimate(registers)))
+}
+
+// Merge adds all items of other to the sketch, leaving other unchanged. Both sketches must have the
+// same precision; otherwise ErrPrecisionMismatch is returned. It returns ErrNilSketch if other is nil.
+func (h *HyperLogLog[T]) Merge(other *HyperLogLog[T]) error {
+	if other == nil {
+		return ErrNilSketch
+	}
+	if other.precision != h.precision {
+		return ErrPrecisionMismatch
+	}
+
+	if other.registers == nil {
+		entries := other.sparseEntries()
+		if h.registers == nil {
+			h.tmp = append(h.tmp, entries...)
+			h.mergeTmp()
+			return nil
+		}
+		for _, k := range entries {
+			idx, rho := h.decodeHash(k)
+			if rho > h.registers[idx] {
+				h.registers[idx] = rho
+			}
+		}
+		return nil
+	}
+
+	h.toDense()
+	for i, rho := range other.registers {
+		if rho > h.registers[i] {
+			h.registers[i] = rho
+		}
+	}
+	return nil
+}
+
+// Clear removes all items from the sketch and returns it to the sparse representation.
+func (h *HyperLogLog[T]) Clear() {
+	h.registers = nil
+	h.sparse = nil
+	h.tmp = nil
+}
+
+// IsEmpty returns true if no items have been added to the sketch.
+func (h *HyperLogLog[T]) IsEmpty() bool {
+	if h.registers == nil {
+		return len(h.sparse) == 0 && len(h.tmp) == 0
+	}
+	for _, rho := range h.registers {
+		if rho != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Precision returns the precision of the sketch.
+func (h *HyperLogLog[T]) Precision() uint8 {
+	return h.precision
+}
+
+// denseIndex returns the register of a hash and the position of the leftmost 1 bit
+// in the remaining 64-p bits.
+func (h *HyperLogLog[T]) denseIndex(x uint64) (uint32, uint8) {
+	p := uint(h.precision)
+	return uint32(x >> (64 - p)), rho(x<<p, 64-p)
+}
+
+// rho returns the position of the leftmost 1 bit of w, capped at width+1 when the width bits are zero.
+func rho(w uint64, width uint) uint8 {
+	r := uint(bits.LeadingZeros64(w)) + 1
+	if r > width+1 {
+		r = width + 1
+	}
+	return uint8(r)
+}
+
+// encodeHash encodes a hash for the sparse representation. The top p' bits are the sparse index.
+// If the bits of the sparse index below the top p are not all zero, they determine the dense rho
+// and the encoding is the sparse index shifted left by one. Otherwise it also holds the rho of the
+// remaining 64-p' bits, and the lowest bit is set:
+//
+//	index (25 bits) | 0                 or    index (25 bits) | rho' (6 bits) | 1
+func (h *HyperLogLog[T]) encodeHash(x uint64) uint32 {
+	idx := uint32(x >> (64 - sparsePrecision))
+	if idx&(1<<(sparsePrecision-h.precision)-1) != 0 {
+		return idx << 1
+	}
+	return idx<<7 | uint32(rho(x<<sparsePrecision, 64-sparsePrecision))<<1 | 1
+}
+
+// sparseIndex returns the sparse index of an encoded hash.
+func sparseIndex(k uint32) uint32 {
+	if k&1 == 1 {
+		return k >> 7
+	}
+	return k >> 1
+}
+
+// encodedLess orders encoded hashes by sparse index, then by rho.
+func encodedLess(a, b uint32) bool {
+	if ia, ib := sparseIndex(a), sparseIndex(b); ia != ib {
+		return ia < ib
+	}
+	return a < b
+}
+
+// decodeHash returns the dense register and rho of an encoded hash.
+func (h *HyperLogLog[T]) decodeHash(k uint32) (uint32, uint8) {
+	shift := uint(sparsePrecision - h.precision)
+	idx := sparseIndex(k)
+	if k&1 == 1 {
+		return idx >> shift, uint8(k>>1&63) + uint8(shift)
+	}
+	low := idx & (1<<shift - 1)
+	return idx >> shift, uint8(bits.LeadingZeros32(low<<(32-shift))) + 1
+}
+
+// tmpLimit returns how many hashes are buffered before being merged into the sparse list.
+func (h *HyperLogLog[T]) tmpLimit() int {
+	limit := (1 << h.precision) / 16
+	if limit < 16 {
+		limit = 16
+	}
+	return limit
+}
+
+// mergeTmp merges the buffered hashes into the sparse list and switches to the dense
+// representation once the sparse list uses more memory than the registers.
+func (h *HyperLogLog[T]) mergeTmp() {
+	if len(h.tmp) == 0 {
+		return
+	}
+	h.sparse = mergeEncoded(h.sparse, h.tmp)
+	h.tmp = h.tmp[:0]
+
+	if h.tooDense(h.sparse) {
+		h.toDense()
+	}
+}
+
+// sparseEntries returns the sparse list with the buffered hashes merged in, without modifying the sketch.
+func (h *HyperLogLog[T]) sparseEntries() []uint32 {
+	if len(h.tmp) == 0 {
+		return h.sparse
+	}
+	return mergeEncoded(h.sparse, append([]uint32(nil), h.tmp...))
+}
+
+// tooDense reports whether a sparse list of the given entries uses more memory than the registers.
+func (h *HyperLogLog[T]) tooDense(entries []uint32) bool {
+	return 4*len(entries) > 1<<h.precision
+}
+
+// mergeEncoded returns the sorted list sparse merged with the encoded hashes in tmp, which are sorted
+// in place. Only the largest rho of every sparse index is kept.
+func mergeEncoded(sparse, tmp []uint32) []uint32 {
+	sort.Slice(
+		tmp, func(i, j int) bool {
+			return encodedLess(tmp[i], tmp[j])
+		},
+	)
+
+	merged := make([]uint32, 0, len(sparse)+len(tmp))
+	i, j := 0, 0
+	for i < len(sparse) || j < len(tmp) {
+		var k uint32
+		if j == len(tmp) || i < len(sparse) && encodedLess(sparse[i], tmp[j]) {
+			k = sparse[i]
+			i++
+		} else {
+			k = tmp[j]
+			j++
+		}
+		// Entries with the same sparse index are adjacent, and the largest rho has the largest encoding
+		if n := len(merged); n > 0 && sparseIndex(merged[n-1]) == sparseIndex(k) {
+			merged[n-1] = k
+		} else {
+			merged = append(merged, k)
+		}
+	}
+	return merged
+}
+
+// toDense switches to the dense representation.
+func (h *HyperLogLog[T]) toDense() {
+	if h.registers != nil {
+		return
+	}
+	h.mergeTmp()
+	if h.registers != nil {
+		return // mergeTmp already switched
+	}
+	h.registers = h.denseRegisters(h.sparse)
+	h.sparse = nil
+	h.tmp = nil
+}
+
+// denseRegisters returns the registers of the dense representation of the sparse entries.
+func (h *HyperLogLog[T]) denseRegisters(entries []uint32) []uint8 {
+	registers := make([]uint8, 1<<h.precision)
+	for _, k := range entries {
+		idx, rho := h.decodeHash(k)
+		if rho > registers[idx] {
+			registers[idx] = rho
+		}
+	}
+	return registers
+}
+
+// estimate returns the cardinality estimate of dense registers using the improved estimator
+// of Ertl, "New cardinality estimation algorithms for HyperLogLog sketches" (2017), which is
+// unbiased over the whole range without the empirical bias tables of HyperLogLog++.
+func (h *HyperLogLog[T]) estimate(registers []uint8) float64 {
+	q := 64 - int(h.precision)
+	m := float64(len(registers))
+
+	counts := make([]int, q+2)
+	for _, rho := range registers {
+		counts[rho]++
+	}
+
+	z := m * tau(1-float64(counts[q+1])/m)
+	for k := q; k >= 1; k-- {
+		z = 0.5 * (z + float64(counts[k]))
+	}
+	z += m * sigma(float64(counts[0])/m)
+	return m * m / (2 * math.Ln2 * z)
+}
+
+func sigma(x float64) float64 {
+	if x == 1 {
+		return math.Inf(1)
+	}
+	y, z := 1.0, x
+	for {
+		x *= x
+		prev := z
+		z += x * y
+		y += y
+		if z == prev {
+			return z
+		}
+	}
+}
+
+func tau(x float64) float64 {
+	if x == 0 || x == 1 {
+		return 0
+	}
+	y, z := 1.0, 1-x
+	for {
+		x = math.Sqrt(x)
+		prev := z
+		y *= 0.5
+		z -= (1 - x) * (1 - x) * y
+		if z == prev {
+			return z / 3
+		}
+	}
+}
+
+// linearCounting estimates the number of distinct items from the number of empty registers.
+func linearCounting(m, empty float64) float64 {
+	return m * math.Log(m/empty)
+}
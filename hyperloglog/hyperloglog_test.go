@@ -0,0 +1,266 @@
+package hyperloglog
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		precision uint8
+		err       error
+	}{
+		{MinPrecision - 1, ErrInvalidPrecision},
+		{MinPrecision, nil},
+		{14, nil},
+		{MaxPrecision, nil},
+		{MaxPrecision + 1, ErrInvalidPrecision},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			fmt.Sprintf("precision %d", tt.precision), func(t *testing.T) {
+				h, err := New[int](tt.precision)
+				if err != tt.err {
+					t.Fatalf("Expected error %v, got %v", tt.err, err)
+				}
+				if err == nil && (h.Precision() != tt.precision || !h.IsEmpty() || h.Count() != 0) {
+					t.Errorf("Expected an empty sketch with precision %d", tt.precision)
+				}
+			},
+		)
+	}
+}
+
+func TestHyperLogLog_Count(t *testing.T) {
+	tests := []struct {
+		n        int
+		maxError float64
+	}{
+		{1, 0},
+		{10, 0},
+		{100, 0.002},
+		{1000, 0.005},
+		{10000, 0.025},
+		{100000, 0.025},
+		{1000000, 0.025},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			fmt.Sprintf("%d items", tt.n), func(t *testing.T) {
+				h, _ := New[string](14)
+				for i := 0; i < tt.n; i++ {
+					item := fmt.Sprintf("user-%d", i)
+					h.Add(item)
+					h.Add(item) // Duplicates must not be counted
+				}
+				assertEstimate(t, h.Count(), tt.n, tt.maxError)
+			},
+		)
+	}
+}
+
+func TestHyperLogLog_Bias(t *testing.T) {
+	// The range around a few times the number of registers is where the raw HyperLogLog
+	// estimate is most biased; the improved estimator must stay within a few standard errors.
+	for _, precision := range []uint8{6, 8, 10, 12, 16} {
+		m := 1 << precision
+		stdErr := 1.04 / math.Sqrt(float64(m))
+		for _, factor := range []float64{0.5, 1, 2.5, 5, 20} {
+			n := int(factor * float64(m))
+			t.Run(
+				fmt.Sprintf("p=%d n=%d", precision, n), func(t *testing.T) {
+					h, _ := New[int](precision)
+					h.toDense()
+					for i := 0; i < n; i++ {
+						h.Add(i + n)
+					}
+					assertEstimate(t, h.Count(), n, 4*stdErr)
+				},
+			)
+		}
+	}
+}
+
+func TestHyperLogLog_SparseMatchesDense(t *testing.T) {
+	sparse, _ := New[int](10)
+	dense, _ := New[int](10)
+	dense.toDense()
+
+	for i := 0; i < 200; i++ {
+		sparse.Add(i)
+		dense.Add(i)
+	}
+	if sparse.registers != nil {
+		t.Fatal("Expected a small sketch to stay sparse")
+	}
+	if sparse.Count() != 200 {
+		t.Errorf("Expected an exact sparse count of 200, got %d", sparse.Count())
+	}
+
+	sparse.toDense()
+	for i, rho := range dense.registers {
+		if sparse.registers[i] != rho {
+			t.Fatalf("Register %d differs: sparse %d, dense %d", i, sparse.registers[i], rho)
+		}
+	}
+}
+
+func TestHyperLogLog_SwitchesToDense(t *testing.T) {
+	h, _ := New[int](8)
+	for i := 0; i < 1000; i++ {
+		h.Add(i)
+	}
+	if h.registers == nil || h.sparse != nil {
+		t.Error("Expected the sketch to switch to the dense representation")
+	}
+	assertEstimate(t, h.Count(), 1000, 4*1.04/16)
+}
+
+func TestHyperLogLog_CountKeepsReceiver(t *testing.T) {
+	tests := []struct {
+		name      string
+		precision uint8
+	}{
+		{"Buffered hashes stay sparse", 12},
+		{"Buffered hashes would switch to dense", 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				h, _ := New[int](tt.precision)
+				for i := 0; i < 10; i++ {
+					h.Add(i)
+				}
+
+				var wg sync.WaitGroup
+				counts := make([]uint64, 4)
+				for i := range counts {
+					wg.Add(1)
+					go func(i int) {
+						defer wg.Done()
+						counts[i] = h.Count()
+					}(i)
+				}
+				wg.Wait()
+				if len(h.tmp) != 10 || h.sparse != nil || h.registers != nil {
+					t.Error("Count should not modify the sketch")
+				}
+
+				h.mergeTmp()
+				for _, count := range counts {
+					if count != h.Count() {
+						t.Errorf("Expected count %d after merging, got %d", h.Count(), count)
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestHyperLogLog_Merge(t *testing.T) {
+	tests := []struct {
+		name   string
+		sizes  [2]int
+		shared int
+	}{
+		{"Sparse into sparse", [2]int{100, 200}, 50},
+		{"Dense into sparse", [2]int{100, 20000}, 50},
+		{"Sparse into dense", [2]int{20000, 100}, 50},
+		{"Dense into dense", [2]int{20000, 30000}, 10000},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				a, _ := New[int](12)
+				b, _ := New[int](12)
+				all, _ := New[int](12)
+				for i := 0; i < tt.sizes[0]; i++ {
+					a.Add(i)
+					all.Add(i)
+				}
+				start := tt.sizes[0] - tt.shared
+				for i := start; i < start+tt.sizes[1]; i++ {
+					b.Add(i)
+					all.Add(i)
+				}
+
+				before, _ := b.MarshalBinary()
+				buffered := len(b.tmp)
+				if err := a.Merge(b); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if after, _ := b.MarshalBinary(); !bytes.Equal(before, after) || len(b.tmp) != buffered {
+					t.Error("Merge should not modify the merged sketch")
+				}
+				if a.Count() != all.Count() {
+					t.Errorf("Expected merged count %d to equal the count of all items %d", a.Count(), all.Count())
+				}
+				assertEstimate(t, a.Count(), tt.sizes[0]+tt.sizes[1]-tt.shared, 0.05)
+			},
+		)
+	}
+
+	a, _ := New[int](12)
+	b, _ := New[int](13)
+	if err := a.Merge(b); err != ErrPrecisionMismatch {
+		t.Errorf("Expected ErrPrecisionMismatch, got %v", err)
+	}
+	if err := a.Merge(nil); err != ErrNilSketch {
+		t.Errorf("Expected ErrNilSketch, got %v", err)
+	}
+}
+
+func TestHyperLogLog_Clear(t *testing.T) {
+	h, _ := New[int](10)
+	for i := 0; i < 5000; i++ {
+		h.Add(i)
+	}
+	if h.IsEmpty() {
+		t.Error("Should not be empty")
+	}
+	h.Clear()
+	if !h.IsEmpty() || h.Count() != 0 || h.registers != nil {
+		t.Error("Should be an empty sparse sketch after clear")
+	}
+}
+
+func assertEstimate(t *testing.T, estimate uint64, n int, maxError float64) {
+	t.Helper()
+	relative := math.Abs(float64(estimate)-float64(n)) / float64(n)
+	if relative > maxError {
+		t.Errorf("Estimate %d for %d items has relative error %.4f, want <= %.4f", estimate, n, relative, maxError)
+	}
+}
+
+func BenchmarkHyperLogLog(b *testing.B) {
+	b.Run(
+		"Add", func(b *testing.B) {
+			h, _ := New[int](14)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				h.Add(i)
+			}
+		},
+	)
+
+	b.Run(
+		"Count", func(b *testing.B) {
+			h, _ := New[int](14)
+			for i := 0; i < 100000; i++ {
+				h.Add(i)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				h.Count()
+			}
+		},
+	)
+}
@@ -0,0 +1,45 @@
+package binenc
+
+import (
+	"bytes"
+	"encoding"
+	"io"
+)
+
+// WriteTo writes the binary encoding of m to w, for implementing io.WriterTo.
+func WriteTo(w io.Writer, m encoding.BinaryMarshaler) (int64, error) {
+	data, err := m.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// ReadFrom reads exactly one encoded value from r and decodes it into u, for implementing io.ReaderFrom.
+// It first reads headerSize bytes, then calls size with the bytes read so far to learn the total length
+// of the encoding, reading more and asking again until it has all of it. size returns an error for an
+// invalid header. The buffer grows as data arrives, so a corrupt header cannot force a huge allocation.
+// An encoding cut short by the end of r returns io.ErrUnexpectedEOF.
+func ReadFrom(
+	r io.Reader, u encoding.BinaryUnmarshaler, headerSize int64, size func(data []byte) (int64, error),
+) (int64, error) {
+	var buf bytes.Buffer
+	for want := headerSize; ; {
+		if _, err := io.CopyN(&buf, r, want-int64(buf.Len())); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return int64(buf.Len()), err
+		}
+		total, err := size(buf.Bytes())
+		if err != nil {
+			return int64(buf.Len()), err
+		}
+		if total <= int64(buf.Len()) {
+			break
+		}
+		want = total
+	}
+	return int64(buf.Len()), u.UnmarshalBinary(buf.Bytes())
+}
@@ -5,6 +5,8 @@ import (
 	"github.com/idsulik/go-collections/v3/iterator"
 )
 
+// Queue is a FIFO queue backed by a circular buffer. The buffer grows as needed and shrinks again
+// when it is sparsely used, so dequeued items do not keep memory alive during long-running churn.
 type Queue[T any] struct {
 	d *deque.Deque[T]
 }
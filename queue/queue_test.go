@@ -2,6 +2,8 @@ package queue
 
 import (
 	"testing"
+
+	"github.com/idsulik/go-collections/v3/internal/slices"
 )
 
 // TestNewQueue tests the creation of a new queue with initial capacity.
@@ -126,3 +128,112 @@ func TestForEach(t *testing.T) {
 		t.Errorf("ForEach() = %d; want 3", sum)
 	}
 }
+
+func TestChurn(t *testing.T) {
+	q := New[int](8)
+
+	// Keep a small backlog while many items pass through, so the buffer wraps around repeatedly
+	next := 0
+	for i := 0; i < 10000; i++ {
+		q.Enqueue(i)
+		if i%2 == 1 {
+			for j := 0; j < 2 && q.Len() > 3; j++ {
+				got, _ := q.Dequeue()
+				if got != next {
+					t.Fatalf("Dequeue() = %d; want %d", got, next)
+				}
+				next++
+			}
+		}
+	}
+
+	var expected []int
+	for i := next; i < 10000; i++ {
+		expected = append(expected, i)
+	}
+	if got := q.GetItems(); !slices.Equal(got, expected) {
+		t.Errorf("GetItems() = %v; want %v", got, expected)
+	}
+	var visited []int
+	q.ForEach(
+		func(item int) {
+			visited = append(visited, item)
+		},
+	)
+	if !slices.Equal(visited, expected) {
+		t.Errorf("ForEach() visited %v; want %v", visited, expected)
+	}
+	var iterated []int
+	for it := q.Iterator(); it.HasNext(); {
+		item, _ := it.Next()
+		iterated = append(iterated, item)
+	}
+	if !slices.Equal(iterated, expected) {
+		t.Errorf("Iterator() yielded %v; want %v", iterated, expected)
+	}
+}
+
+func TestShrinkAfterBurst(t *testing.T) {
+	q := New[int](8)
+	for i := 0; i < 100000; i++ {
+		q.Enqueue(i)
+	}
+	for i := 0; i < 99990; i++ {
+		q.Dequeue()
+	}
+
+	if got := q.d.Cap(); got > 64 {
+		t.Errorf("buffer capacity after draining a burst = %d; want at most 64", got)
+	}
+	if got, _ := q.Peek(); got != 99990 {
+		t.Errorf("Peek() = %d; want 99990", got)
+	}
+}
+
+func BenchmarkChurn(b *testing.B) {
+	b.Run(
+		"SteadyState", func(b *testing.B) {
+			q := New[int](0)
+			for i := 0; i < 1000; i++ {
+				q.Enqueue(i)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				q.Enqueue(i)
+				q.Dequeue()
+			}
+		},
+	)
+
+	b.Run(
+		"Bursts", func(b *testing.B) {
+			q := New[int](0)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i += 10000 {
+				for j := 0; j < 10000; j++ {
+					q.Enqueue(j)
+				}
+				for j := 0; j < 10000; j++ {
+					q.Dequeue()
+				}
+			}
+		},
+	)
+
+	b.Run(
+		"SliceSteadyState", func(b *testing.B) {
+			// Baseline: a slice queue that reslices on dequeue and relies on append to grow
+			items := make([]int, 0, 1000)
+			for i := 0; i < 1000; i++ {
+				items = append(items, i)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				items = append(items, i)
+				items = items[1:]
+			}
+		},
+	)
+}
@@ -0,0 +1,307 @@
+package ringbuffer
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+var (
+	// ErrFull is returned by a non-blocking ByteBuffer when not all bytes could be written
+	ErrFull = errors.New("ringbuffer: buffer is full")
+
+	// ErrEmpty is returned by a non-blocking ByteBuffer when there are no bytes to read
+	ErrEmpty = errors.New("ringbuffer: buffer is empty")
+
+	// ErrClosed is returned when writing to a closed ByteBuffer
+	ErrClosed = errors.New("ringbuffer: buffer is closed")
+)
+
+// ByteBuffer is a ring buffer of bytes implementing io.Reader, io.Writer, io.ByteReader,
+// io.ByteWriter, io.WriterTo, io.ReaderFrom and io.Closer. Bulk transfers copy whole regions of
+// the buffer instead of moving one byte at a time. It is safe for concurrent use.
+//
+// By default it never blocks: writes store what fits and return ErrFull, and reads from an empty
+// buffer return ErrEmpty. A buffer created WithBlocking instead waits for space or data, so that
+// producer and consumer goroutines can stream through it; Close then signals the end of the stream.
+// In both modes, reads return io.EOF once the buffer is closed and drained.
+type ByteBuffer struct {
+	buf      []byte
+	r        int // next read position
+	w        int // next write position
+	count    int
+	closed   bool
+	blocking bool
+
+	mu       sync.Mutex
+	notEmpty sync.Cond
+	notFull  sync.Cond
+	// readMu and writeMu serialize readers and writers, so that WriteTo and ReadFrom can transfer
+	// a region of the buffer without holding mu while calling the destination or source
+	readMu  sync.Mutex
+	writeMu sync.Mutex
+}
+
+// ByteBufferOption is a function that configures a ByteBuffer
+type ByteBufferOption func(*ByteBuffer)
+
+// WithBlocking makes reads wait for data and writes wait for space instead of returning ErrEmpty and ErrFull
+func WithBlocking() ByteBufferOption {
+	return func(b *ByteBuffer) {
+		b.blocking = true
+	}
+}
+
+// NewByteBuffer creates a new ByteBuffer with the specified capacity
+func NewByteBuffer(capacity int, opts ...ByteBufferOption) *ByteBuffer {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	b := &ByteBuffer{buf: make([]byte, capacity)}
+	b.notEmpty.L = &b.mu
+	b.notFull.L = &b.mu
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Write writes p to the buffer. A non-blocking buffer writes the bytes that fit and returns ErrFull
+// if that is not all of them; a blocking buffer waits until all bytes are written.
+// It returns ErrClosed once the buffer is closed.
+func (b *ByteBuffer) Write(p []byte) (int, error) {
+	b.writeMu.Lock()
+	defer b.writeMu.Unlock()
+
+	n := 0
+	for len(p) > 0 {
+		b.mu.Lock()
+		region, err := b.freeRegion()
+		if err != nil {
+			b.mu.Unlock()
+			return n, err
+		}
+		k := copy(region, p)
+		b.commitWrite(k)
+		b.mu.Unlock()
+
+		p = p[k:]
+		n += k
+	}
+	return n, nil
+}
+
+// WriteByte writes a single byte to the buffer
+func (b *ByteBuffer) WriteByte(c byte) error {
+	_, err := b.Write([]byte{c})
+	return err
+}
+
+// ReadFrom reads from src into the buffer until src returns io.EOF, which is not reported as an error.
+// A non-blocking buffer stops with ErrFull when it fills up; a blocking buffer waits for space.
+func (b *ByteBuffer) ReadFrom(src io.Reader) (int64, error) {
+	b.writeMu.Lock()
+	defer b.writeMu.Unlock()
+
+	var n int64
+	for {
+		b.mu.Lock()
+		region, err := b.freeRegion()
+		b.mu.Unlock()
+		if err != nil {
+			return n, err
+		}
+
+		// Only this writer touches the free region, so src can fill it without holding mu
+		k, err := src.Read(region)
+		b.mu.Lock()
+		b.commitWrite(k)
+		b.mu.Unlock()
+
+		n += int64(k)
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+	}
+}
+
+// Read reads up to len(p) bytes from the buffer. A blocking buffer waits until at least one byte is
+// available; a non-blocking one returns ErrEmpty if there is none. It returns io.EOF once the
+// buffer is closed and drained.
+func (b *ByteBuffer) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	b.readMu.Lock()
+	defer b.readMu.Unlock()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	region, err := b.dataRegion()
+	if err != nil {
+		return 0, err
+	}
+	n := copy(p, region)
+	if n < len(p) && n < b.count {
+		// The data wraps around the end of the buffer
+		n += copy(p[n:], b.buf[:b.count-n])
+	}
+	b.commitRead(n)
+	return n, nil
+}
+
+// ReadByte reads a single byte from the buffer, with the same blocking behavior as Read
+func (b *ByteBuffer) ReadByte() (byte, error) {
+	b.readMu.Lock()
+	defer b.readMu.Unlock()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	region, err := b.dataRegion()
+	if err != nil {
+		return 0, err
+	}
+	c := region[0]
+	b.commitRead(1)
+	return c, nil
+}
+
+// WriteTo writes the buffered bytes to dst until the buffer is drained. A blocking buffer keeps
+// waiting for more data until it is closed. Reaching the end is not reported as an error.
+func (b *ByteBuffer) WriteTo(dst io.Writer) (int64, error) {
+	b.readMu.Lock()
+	defer b.readMu.Unlock()
+
+	var n int64
+	for {
+		b.mu.Lock()
+		region, err := b.dataRegion()
+		b.mu.Unlock()
+		if err == io.EOF || err == ErrEmpty {
+			return n, nil
+		}
+
+		// Only this reader consumes the data region, so dst can read it without holding mu
+		k, err := dst.Write(region)
+		b.mu.Lock()
+		b.commitRead(k)
+		b.mu.Unlock()
+
+		n += int64(k)
+		if err != nil {
+			return n, err
+		}
+		if k < len(region) {
+			return n, io.ErrShortWrite
+		}
+	}
+}
+
+// freeRegion waits as configured for free space and returns the contiguous free region
+// starting at the write position. It must be called with mu held.
+func (b *ByteBuffer) freeRegion() ([]byte, error) {
+	for b.blocking && b.count == len(b.buf) && !b.closed {
+		b.notFull.Wait()
+	}
+	if b.closed {
+		return nil, ErrClosed
+	}
+	if b.count == len(b.buf) {
+		return nil, ErrFull
+	}
+	end := b.w + len(b.buf) - b.count
+	if end > len(b.buf) {
+		end = len(b.buf)
+	}
+	return b.buf[b.w:end], nil
+}
+
+// dataRegion waits as configured for data and returns the contiguous buffered bytes
+// starting at the read position. It must be called with mu held.
+func (b *ByteBuffer) dataRegion() ([]byte, error) {
+	for b.blocking && b.count == 0 && !b.closed {
+		b.notEmpty.Wait()
+	}
+	if b.count == 0 {
+		if b.closed {
+			return nil, io.EOF
+		}
+		return nil, ErrEmpty
+	}
+	end := b.r + b.count
+	if end > len(b.buf) {
+		end = len(b.buf)
+	}
+	return b.buf[b.r:end], nil
+}
+
+func (b *ByteBuffer) commitWrite(n int) {
+	if n == 0 {
+		return
+	}
+	b.w = (b.w + n) % len(b.buf)
+	b.count += n
+	b.notEmpty.Broadcast()
+}
+
+func (b *ByteBuffer) commitRead(n int) {
+	if n == 0 {
+		return
+	}
+	b.r = (b.r + n) % len(b.buf)
+	b.count -= n
+	b.notFull.Broadcast()
+}
+
+// Close marks the end of the stream: later writes return ErrClosed, and reads return io.EOF
+// once the remaining bytes have been read. Blocked readers and writers are woken up.
+func (b *ByteBuffer) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	b.notEmpty.Broadcast()
+	b.notFull.Broadcast()
+	return nil
+}
+
+// Len returns the number of buffered bytes
+func (b *ByteBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.count
+}
+
+// Cap returns the capacity of the buffer
+func (b *ByteBuffer) Cap() int {
+	return len(b.buf)
+}
+
+// Free returns the number of bytes that can be written without blocking
+func (b *ByteBuffer) Free() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.buf) - b.count
+}
+
+// IsEmpty returns true if the buffer contains no bytes
+func (b *ByteBuffer) IsEmpty() bool {
+	return b.Len() == 0
+}
+
+// IsFull returns true if the buffer is at capacity
+func (b *ByteBuffer) IsFull() bool {
+	return b.Free() == 0
+}
+
+// Reset discards the buffered bytes and reopens the buffer if it was closed.
+// It must not be called while other goroutines use the buffer.
+func (b *ByteBuffer) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.r, b.w, b.count = 0, 0, 0
+	b.closed = false
+	b.notFull.Broadcast()
+}
@@ -0,0 +1,379 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestByteBuffer(t *testing.T) {
+	t.Run(
+		"New buffer creation", func(t *testing.T) {
+			b := NewByteBuffer(8)
+			if b.Cap() != 8 {
+				t.Errorf("Expected capacity of 8, got %d", b.Cap())
+			}
+			if !b.IsEmpty() || b.Free() != 8 {
+				t.Error("New buffer should be empty")
+			}
+			if NewByteBuffer(0).Cap() != 1 {
+				t.Error("Non-positive capacity should fall back to 1")
+			}
+		},
+	)
+
+	t.Run(
+		"Write and read", func(t *testing.T) {
+			b := NewByteBuffer(8)
+			n, err := b.Write([]byte("hello"))
+			if n != 5 || err != nil {
+				t.Fatalf("Write() = %d, %v; want 5, nil", n, err)
+			}
+			if b.Len() != 5 || b.Free() != 3 {
+				t.Errorf("Expected Len 5 and Free 3, got %d and %d", b.Len(), b.Free())
+			}
+
+			p := make([]byte, 3)
+			n, err = b.Read(p)
+			if n != 3 || err != nil || string(p) != "hel" {
+				t.Errorf("Read() = %d, %v, %q; want 3, nil, \"hel\"", n, err, p)
+			}
+			n, err = b.Read(p)
+			if n != 2 || err != nil || string(p[:n]) != "lo" {
+				t.Errorf("Read() = %d, %v, %q; want 2, nil, \"lo\"", n, err, p[:n])
+			}
+		},
+	)
+
+	t.Run(
+		"Short write when full", func(t *testing.T) {
+			b := NewByteBuffer(4)
+			n, err := b.Write([]byte("abcdef"))
+			if n != 4 || !errors.Is(err, ErrFull) {
+				t.Errorf("Write() = %d, %v; want 4, ErrFull", n, err)
+			}
+			if !b.IsFull() {
+				t.Error("Buffer should be full")
+			}
+			if err := b.WriteByte('x'); !errors.Is(err, ErrFull) {
+				t.Errorf("WriteByte() = %v, want ErrFull", err)
+			}
+		},
+	)
+
+	t.Run(
+		"Read from empty buffer", func(t *testing.T) {
+			b := NewByteBuffer(4)
+			if n, err := b.Read(make([]byte, 4)); n != 0 || !errors.Is(err, ErrEmpty) {
+				t.Errorf("Read() = %d, %v; want 0, ErrEmpty", n, err)
+			}
+			if _, err := b.ReadByte(); !errors.Is(err, ErrEmpty) {
+				t.Errorf("ReadByte() = %v, want ErrEmpty", err)
+			}
+			if n, err := b.Read(nil); n != 0 || err != nil {
+				t.Errorf("Read(nil) = %d, %v; want 0, nil", n, err)
+			}
+		},
+	)
+
+	t.Run(
+		"Wrap around", func(t *testing.T) {
+			b := NewByteBuffer(5)
+			b.Write([]byte("abc"))
+			b.Read(make([]byte, 2))
+			if n, err := b.Write([]byte("defg")); n != 4 || err != nil {
+				t.Fatalf("Write() = %d, %v; want 4, nil", n, err)
+			}
+
+			p := make([]byte, 10)
+			n, err := b.Read(p)
+			if err != nil || string(p[:n]) != "cdefg" {
+				t.Errorf("Read() = %q, %v; want \"cdefg\", nil", p[:n], err)
+			}
+		},
+	)
+
+	t.Run(
+		"ReadByte and WriteByte", func(t *testing.T) {
+			b := NewByteBuffer(2)
+			for i := 0; i < 5; i++ {
+				if err := b.WriteByte(byte('a' + i)); err != nil {
+					t.Fatalf("WriteByte() error: %v", err)
+				}
+				c, err := b.ReadByte()
+				if err != nil || c != byte('a'+i) {
+					t.Errorf("ReadByte() = %q, %v; want %q, nil", c, err, 'a'+i)
+				}
+			}
+		},
+	)
+
+	t.Run(
+		"Close", func(t *testing.T) {
+			b := NewByteBuffer(4)
+			b.Write([]byte("ab"))
+			if err := b.Close(); err != nil {
+				t.Fatalf("Close() error: %v", err)
+			}
+			if _, err := b.Write([]byte("c")); !errors.Is(err, ErrClosed) {
+				t.Errorf("Write() after Close = %v, want ErrClosed", err)
+			}
+
+			data, err := io.ReadAll(b)
+			if err != nil || string(data) != "ab" {
+				t.Errorf("ReadAll() = %q, %v; want \"ab\", nil", data, err)
+			}
+			if _, err := b.ReadByte(); err != io.EOF {
+				t.Errorf("ReadByte() after drain = %v, want io.EOF", err)
+			}
+		},
+	)
+
+	t.Run(
+		"Reset", func(t *testing.T) {
+			b := NewByteBuffer(4)
+			b.Write([]byte("abc"))
+			b.Close()
+			b.Reset()
+			if !b.IsEmpty() {
+				t.Error("Buffer should be empty after Reset")
+			}
+			if n, err := b.Write([]byte("xyzw")); n != 4 || err != nil {
+				t.Errorf("Write() after Reset = %d, %v; want 4, nil", n, err)
+			}
+		},
+	)
+}
+
+func TestByteBuffer_WriteTo(t *testing.T) {
+	b := NewByteBuffer(6)
+	b.Write([]byte("abcd"))
+	b.Read(make([]byte, 3))
+	b.Write([]byte("efghi"))
+
+	var out bytes.Buffer
+	n, err := b.WriteTo(&out)
+	if n != 6 || err != nil || out.String() != "defghi" {
+		t.Errorf("WriteTo() = %d, %v, %q; want 6, nil, \"defghi\"", n, err, out.String())
+	}
+	if !b.IsEmpty() {
+		t.Error("Buffer should be empty after WriteTo")
+	}
+
+	t.Run(
+		"Short write", func(t *testing.T) {
+			b := NewByteBuffer(8)
+			b.Write([]byte("abcdef"))
+			n, err := b.WriteTo(&limitedWriter{limit: 4})
+			if n != 4 || !errors.Is(err, io.ErrShortWrite) {
+				t.Errorf("WriteTo() = %d, %v; want 4, io.ErrShortWrite", n, err)
+			}
+			if b.Len() != 2 {
+				t.Errorf("Expected 2 bytes left, got %d", b.Len())
+			}
+		},
+	)
+}
+
+func TestByteBuffer_ReadFrom(t *testing.T) {
+	t.Run(
+		"Source fits", func(t *testing.T) {
+			b := NewByteBuffer(8)
+			b.Write([]byte("abcde"))
+			b.Read(make([]byte, 4))
+
+			n, err := b.ReadFrom(strings.NewReader("fghijk"))
+			if n != 6 || err != nil {
+				t.Fatalf("ReadFrom() = %d, %v; want 6, nil", n, err)
+			}
+			data, _ := io.ReadAll(io.LimitReader(b, int64(b.Len())))
+			if string(data) != "efghijk" {
+				t.Errorf("Expected \"efghijk\", got %q", data)
+			}
+		},
+	)
+
+	t.Run(
+		"Source larger than buffer", func(t *testing.T) {
+			b := NewByteBuffer(4)
+			n, err := b.ReadFrom(strings.NewReader("abcdef"))
+			if n != 4 || !errors.Is(err, ErrFull) {
+				t.Errorf("ReadFrom() = %d, %v; want 4, ErrFull", n, err)
+			}
+		},
+	)
+
+	t.Run(
+		"Source error", func(t *testing.T) {
+			b := NewByteBuffer(4)
+			failure := errors.New("failure")
+			n, err := b.ReadFrom(io.MultiReader(strings.NewReader("ab"), &errReader{err: failure}))
+			if n != 2 || !errors.Is(err, failure) {
+				t.Errorf("ReadFrom() = %d, %v; want 2, failure", n, err)
+			}
+		},
+	)
+}
+
+func TestByteBuffer_Blocking(t *testing.T) {
+	t.Run(
+		"Producer and consumer", func(t *testing.T) {
+			b := NewByteBuffer(64, WithBlocking())
+			data := make([]byte, 1<<16)
+			for i := range data {
+				data[i] = byte(i * 7)
+			}
+
+			go func() {
+				for i := 0; i < len(data); i += 100 {
+					end := i + 100
+					if end > len(data) {
+						end = len(data)
+					}
+					if _, err := b.Write(data[i:end]); err != nil {
+						t.Errorf("Write() error: %v", err)
+					}
+				}
+				b.Close()
+			}()
+
+			var out bytes.Buffer
+			n, err := b.WriteTo(&out)
+			if n != int64(len(data)) || err != nil {
+				t.Fatalf("WriteTo() = %d, %v; want %d, nil", n, err, len(data))
+			}
+			if !bytes.Equal(out.Bytes(), data) {
+				t.Error("Consumer received different bytes than produced")
+			}
+		},
+	)
+
+	t.Run(
+		"ReadFrom and Read", func(t *testing.T) {
+			b := NewByteBuffer(16, WithBlocking())
+			src := strings.Repeat("0123456789", 100)
+
+			go func() {
+				if _, err := b.ReadFrom(strings.NewReader(src)); err != nil {
+					t.Errorf("ReadFrom() error: %v", err)
+				}
+				b.Close()
+			}()
+
+			data, err := io.ReadAll(b)
+			if err != nil || string(data) != src {
+				t.Errorf("ReadAll() returned %d bytes, %v; want %d bytes, nil", len(data), err, len(src))
+			}
+		},
+	)
+
+	t.Run(
+		"Read waits for data", func(t *testing.T) {
+			b := NewByteBuffer(4, WithBlocking())
+			done := make(chan byte)
+			go func() {
+				c, _ := b.ReadByte()
+				done <- c
+			}()
+
+			select {
+			case <-done:
+				t.Fatal("ReadByte should block on an empty buffer")
+			case <-time.After(10 * time.Millisecond):
+			}
+			b.WriteByte('x')
+			if c := <-done; c != 'x' {
+				t.Errorf("Expected 'x', got %q", c)
+			}
+		},
+	)
+
+	t.Run(
+		"Close wakes blocked writers and readers", func(t *testing.T) {
+			b := NewByteBuffer(2, WithBlocking())
+			var wg sync.WaitGroup
+			wg.Add(2)
+
+			var writeErr error
+			go func() {
+				defer wg.Done()
+				_, writeErr = b.Write([]byte("abc"))
+			}()
+
+			for b.Free() > 0 {
+				time.Sleep(time.Millisecond)
+			}
+			b.Close()
+			go func() {
+				defer wg.Done()
+				// Drains the two buffered bytes, then stops at the end of the stream
+				io.ReadAll(b)
+			}()
+			wg.Wait()
+
+			if !errors.Is(writeErr, ErrClosed) {
+				t.Errorf("Blocked Write() = %v, want ErrClosed", writeErr)
+			}
+			if _, err := b.Read(make([]byte, 1)); err != io.EOF {
+				t.Errorf("Read() after drain = %v, want io.EOF", err)
+			}
+		},
+	)
+}
+
+type limitedWriter struct {
+	limit int
+	n     int
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if w.n+len(p) > w.limit {
+		p = p[:w.limit-w.n]
+	}
+	w.n += len(p)
+	return len(p), nil
+}
+
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+func BenchmarkByteBuffer(b *testing.B) {
+	b.Run(
+		"WriteRead", func(b *testing.B) {
+			buf := NewByteBuffer(4096)
+			chunk := make([]byte, 1000)
+			b.SetBytes(int64(len(chunk)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				buf.Write(chunk)
+				buf.Read(chunk)
+			}
+		},
+	)
+
+	b.Run(
+		"RingBufferByte", func(b *testing.B) {
+			rb := New[byte](4096)
+			chunk := make([]byte, 1000)
+			b.SetBytes(int64(len(chunk)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for _, c := range chunk {
+					rb.Write(c)
+				}
+				for j := range chunk {
+					chunk[j], _ = rb.Read()
+				}
+			}
+		},
+	)
+}
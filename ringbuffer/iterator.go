@@ -0,0 +1,44 @@
+package ringbuffer
+
+import (
+	"github.com/idsulik/go-collections/v3/iterator"
+)
+
+// Iterator implements iterator.Iterator for RingBuffer
+type Iterator[T any] struct {
+	current int
+	items   []T
+}
+
+// NewIterator creates a new iterator for the ring buffer
+func NewIterator[T any](r *RingBuffer[T]) iterator.Iterator[T] {
+	// Take a snapshot of current buffer items
+	// This ensures modifications to the buffer won't affect iteration
+	return &Iterator[T]{
+		items: r.PeekN(r.Len()),
+	}
+}
+
+// HasNext returns true if there are more elements to iterate over
+func (it *Iterator[T]) HasNext() bool {
+	return it.current < len(it.items)
+}
+
+// Next returns the next element in the iteration
+// Returns the zero value and false if there are no more elements
+func (it *Iterator[T]) Next() (T, bool) {
+	if !it.HasNext() {
+		var zero T
+		return zero, false
+	}
+
+	value := it.items[it.current]
+	it.current++
+	return value, true
+}
+
+// Reset restarts iteration from the beginning
+// Uses the same snapshot of items from when iterator was created
+func (it *Iterator[T]) Reset() {
+	it.current = 0
+}
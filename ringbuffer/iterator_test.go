@@ -0,0 +1,39 @@
+package ringbuffer
+
+import (
+	"testing"
+)
+
+func TestIterator(t *testing.T) {
+	rb := New[int](3)
+	rb.WriteSlice([]int{1, 2, 3})
+	rb.Read()
+	rb.Write(4)
+
+	it := NewIterator(rb)
+	rb.Clear() // The iterator works on a snapshot
+
+	for _, expected := range []int{2, 3, 4} {
+		if !it.HasNext() {
+			t.Fatalf("Expected more items")
+		}
+		if item, ok := it.Next(); !ok || item != expected {
+			t.Errorf("Next() = %d, %v, want %d", item, ok, expected)
+		}
+	}
+	if item, ok := it.Next(); ok || item != 0 {
+		t.Errorf("Expected zero value and false at the end, got %d, %v", item, ok)
+	}
+
+	it.Reset()
+	if item, _ := it.Next(); item != 2 {
+		t.Errorf("Expected 2 after reset, got %d", item)
+	}
+}
+
+func TestIterator_Empty(t *testing.T) {
+	it := New[string](2).Iterator()
+	if it.HasNext() {
+		t.Error("HasNext() should return false for an empty buffer")
+	}
+}
@@ -0,0 +1,100 @@
+package ringbuffer
+
+import (
+	"sync/atomic"
+)
+
+// MPMC is a lock-free bounded ring buffer for any number of producer and consumer goroutines,
+// based on Dmitry Vyukov's bounded MPMC queue. Each slot carries a sequence number that tells
+// producers and consumers whether it is ready to be written or read at a given position, so
+// claiming a position only takes a compare-and-swap.
+type MPMC[T any] struct {
+	// The 64-bit counters come first so that they are aligned for atomic access on 32-bit platforms
+	tail   uint64 // next write position
+	_      [cacheLineSize - 8]byte
+	head   uint64 // next read position
+	_      [cacheLineSize - 8]byte
+	seqs   []uint64 // kept apart from the items so that each sequence number is 64-bit aligned
+	buffer []T
+	mask   uint64
+}
+
+// NewMPMC creates a new MPMC ring buffer. The capacity is rounded up to a power of two, and is at least 2.
+func NewMPMC[T any](capacity int) *MPMC[T] {
+	size := roundUpPow2(capacity)
+	if size < 2 {
+		size = 2
+	}
+	r := &MPMC[T]{
+		seqs:   make([]uint64, size),
+		buffer: make([]T, size),
+		mask:   size - 1,
+	}
+	for i := range r.seqs {
+		r.seqs[i] = uint64(i)
+	}
+	return r
+}
+
+// TryWrite adds an item to the buffer without blocking. It returns false if the buffer is full.
+func (r *MPMC[T]) TryWrite(item T) bool {
+	pos := atomic.LoadUint64(&r.tail)
+	for {
+		idx := pos & r.mask
+		seq := atomic.LoadUint64(&r.seqs[idx])
+		switch diff := int64(seq - pos); {
+		case diff == 0:
+			// The slot is free for this position; claim it
+			if atomic.CompareAndSwapUint64(&r.tail, pos, pos+1) {
+				r.buffer[idx] = item
+				atomic.StoreUint64(&r.seqs[idx], pos+1)
+				return true
+			}
+			pos = atomic.LoadUint64(&r.tail)
+		case diff < 0:
+			// The slot still holds the item from the previous lap
+			return false
+		default:
+			// Another producer claimed this position
+			pos = atomic.LoadUint64(&r.tail)
+		}
+	}
+}
+
+// TryRead removes and returns the oldest item without blocking. It returns false if the buffer is empty.
+func (r *MPMC[T]) TryRead() (T, bool) {
+	var zero T
+	pos := atomic.LoadUint64(&r.head)
+	for {
+		idx := pos & r.mask
+		seq := atomic.LoadUint64(&r.seqs[idx])
+		switch diff := int64(seq - (pos + 1)); {
+		case diff == 0:
+			// The slot holds the item for this position; claim it
+			if atomic.CompareAndSwapUint64(&r.head, pos, pos+1) {
+				item := r.buffer[idx]
+				r.buffer[idx] = zero
+				atomic.StoreUint64(&r.seqs[idx], pos+r.mask+1)
+				return item, true
+			}
+			pos = atomic.LoadUint64(&r.head)
+		case diff < 0:
+			// The slot has not been written for this position yet
+			return zero, false
+		default:
+			// Another consumer claimed this position
+			pos = atomic.LoadUint64(&r.head)
+		}
+	}
+}
+
+// Len returns the number of items in the buffer. The result is only a snapshot
+// while producers or consumers are running.
+func (r *MPMC[T]) Len() int {
+	return snapshotLen(&r.head, &r.tail, len(r.buffer))
+}
+
+// Cap returns the capacity of the buffer
+func (r *MPMC[T]) Cap() int {
+	return len(r.buffer)
+}
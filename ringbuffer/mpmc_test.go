@@ -0,0 +1,198 @@
+package ringbuffer
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestMPMC(t *testing.T) {
+	t.Run(
+		"Capacity is at least 2", func(t *testing.T) {
+			if got := NewMPMC[int](1).Cap(); got != 2 {
+				t.Errorf("NewMPMC(1).Cap() = %d, want 2", got)
+			}
+			if got := NewMPMC[int](5).Cap(); got != 8 {
+				t.Errorf("NewMPMC(5).Cap() = %d, want 8", got)
+			}
+		},
+	)
+
+	t.Run(
+		"Full and empty", func(t *testing.T) {
+			r := NewMPMC[int](4)
+			if _, ok := r.TryRead(); ok {
+				t.Error("TryRead should fail on an empty buffer")
+			}
+			for i := 0; i < 4; i++ {
+				if !r.TryWrite(i) {
+					t.Fatalf("TryWrite(%d) should succeed", i)
+				}
+			}
+			if r.TryWrite(4) {
+				t.Error("TryWrite should fail on a full buffer")
+			}
+			if r.Len() != 4 {
+				t.Errorf("Expected length 4, got %d", r.Len())
+			}
+			for i := 0; i < 4; i++ {
+				item, ok := r.TryRead()
+				if !ok || item != i {
+					t.Errorf("TryRead() = %d, %v; want %d, true", item, ok, i)
+				}
+			}
+			if r.Len() != 0 {
+				t.Errorf("Expected length 0, got %d", r.Len())
+			}
+		},
+	)
+
+	t.Run(
+		"FIFO order across wrap around", func(t *testing.T) {
+			r := NewMPMC[int](2)
+			for i := 0; i < 10; i++ {
+				if !r.TryWrite(i) {
+					t.Fatalf("TryWrite(%d) should succeed", i)
+				}
+				item, ok := r.TryRead()
+				if !ok || item != i {
+					t.Fatalf("TryRead() = %d, %v; want %d, true", item, ok, i)
+				}
+			}
+		},
+	)
+}
+
+func TestMPMC_Concurrent(t *testing.T) {
+	const (
+		producers = 4
+		consumers = 4
+		perWriter = 20000
+	)
+	r := NewMPMC[int](64)
+
+	var producersWg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		producersWg.Add(1)
+		go func(p int) {
+			defer producersWg.Done()
+			for i := 0; i < perWriter; i++ {
+				for !r.TryWrite(p*perWriter + i) {
+					runtime.Gosched()
+				}
+			}
+		}(p)
+	}
+
+	seen := make([][]int, consumers)
+	var consumersWg sync.WaitGroup
+	remaining := make(chan struct{}, producers*perWriter)
+	for i := 0; i < producers*perWriter; i++ {
+		remaining <- struct{}{}
+	}
+	for c := 0; c < consumers; c++ {
+		consumersWg.Add(1)
+		go func(c int) {
+			defer consumersWg.Done()
+			for range remaining {
+				item, ok := r.TryRead()
+				for !ok {
+					runtime.Gosched()
+					item, ok = r.TryRead()
+				}
+				seen[c] = append(seen[c], item)
+			}
+		}(c)
+	}
+
+	producersWg.Wait()
+	close(remaining)
+	consumersWg.Wait()
+
+	counts := make([]int, producers*perWriter)
+	for c := range seen {
+		// Items of one producer must reach each consumer in the order they were written
+		last := make([]int, producers)
+		for p := range last {
+			last[p] = -1
+		}
+		for _, item := range seen[c] {
+			counts[item]++
+			p := item / perWriter
+			if item <= last[p] {
+				t.Fatalf("Consumer %d read %d after %d", c, item, last[p])
+			}
+			last[p] = item
+		}
+	}
+	for item, count := range counts {
+		if count != 1 {
+			t.Fatalf("Item %d was read %d times, want 1", item, count)
+		}
+	}
+}
+
+func BenchmarkMPMC(b *testing.B) {
+	const workers = 4
+
+	b.Run(
+		"MPMC", func(b *testing.B) {
+			r := NewMPMC[int](1024)
+			b.ReportAllocs()
+			runWorkers(
+				b.N, workers,
+				func(i int) {
+					for !r.TryWrite(i) {
+						runtime.Gosched()
+					}
+				},
+				func() {
+					for _, ok := r.TryRead(); !ok; _, ok = r.TryRead() {
+						runtime.Gosched()
+					}
+				},
+			)
+		},
+	)
+
+	b.Run(
+		"Channel", func(b *testing.B) {
+			ch := make(chan int, 1024)
+			b.ReportAllocs()
+			runWorkers(
+				b.N, workers,
+				func(i int) {
+					ch <- i
+				},
+				func() {
+					<-ch
+				},
+			)
+		},
+	)
+}
+
+// runWorkers transfers n items with the given number of producer and consumer goroutines each
+func runWorkers(n, workers int, produce func(i int), consume func()) {
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		count := n / workers
+		if w < n%workers {
+			count++
+		}
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < count; i++ {
+				produce(i)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < count; i++ {
+				consume()
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -1,29 +1,54 @@
 package ringbuffer
 
+import (
+	"github.com/idsulik/go-collections/v3/iterator"
+)
+
 // RingBuffer represents a circular buffer of fixed size
 type RingBuffer[T any] struct {
-	buffer []T
-	size   int
-	head   int // points to the next write position
-	tail   int // points to the next read position
-	count  int // number of elements currently in buffer
+	buffer    []T
+	size      int
+	head      int // points to the next write position
+	tail      int // points to the next read position
+	count     int // number of elements currently in buffer
+	overwrite bool
+}
+
+// Option is a function that configures a RingBuffer
+type Option[T any] func(*RingBuffer[T])
+
+// WithOverwrite makes Write and WriteSlice evict the oldest items when the buffer is full
+// instead of rejecting new ones
+func WithOverwrite[T any]() Option[T] {
+	return func(r *RingBuffer[T]) {
+		r.overwrite = true
+	}
 }
 
 // New creates a new RingBuffer with the specified capacity
-func New[T any](capacity int) *RingBuffer[T] {
+func New[T any](capacity int, opts ...Option[T]) *RingBuffer[T] {
 	if capacity <= 0 {
 		capacity = 1
 	}
-	return &RingBuffer[T]{
+	r := &RingBuffer[T]{
 		buffer: make([]T, capacity),
 		size:   capacity,
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
-// Write adds an item to the buffer, overwriting the oldest item if the buffer is full
+// Write adds an item to the buffer. If the buffer is full, it returns false,
+// unless the buffer was created WithOverwrite, in which case the oldest item is evicted
 func (r *RingBuffer[T]) Write(item T) bool {
 	if r.count == r.size {
-		return false // Buffer is full
+		if !r.overwrite {
+			return false // Buffer is full
+		}
+		r.Overwrite(item)
+		return true
 	}
 
 	r.buffer[r.head] = item
@@ -32,6 +57,51 @@ func (r *RingBuffer[T]) Write(item T) bool {
 	return true
 }
 
+// Overwrite adds an item to the buffer, evicting the oldest item if the buffer is full.
+// It returns the evicted item and true, or false if nothing was evicted
+func (r *RingBuffer[T]) Overwrite(item T) (T, bool) {
+	var evicted T
+	if r.count < r.size {
+		r.buffer[r.head] = item
+		r.head = (r.head + 1) % r.size
+		r.count++
+		return evicted, false
+	}
+
+	// The buffer is full, so the write position is also the oldest item
+	evicted = r.buffer[r.head]
+	r.buffer[r.head] = item
+	r.head = (r.head + 1) % r.size
+	r.tail = r.head
+	return evicted, true
+}
+
+// WriteSlice adds items to the buffer in order and returns the number of items written.
+// If the buffer fills up, the remaining items are rejected, unless the buffer was created
+// WithOverwrite, in which case all items are written and only the newest Cap() items are kept
+func (r *RingBuffer[T]) WriteSlice(items []T) int {
+	if r.overwrite {
+		if len(items) >= r.size {
+			// Only the last items survive, so replace the whole content
+			copy(r.buffer, items[len(items)-r.size:])
+			r.head, r.tail, r.count = 0, 0, r.size
+			return len(items)
+		}
+		if excess := r.count + len(items) - r.size; excess > 0 {
+			r.discard(excess)
+		}
+	} else if free := r.size - r.count; len(items) > free {
+		items = items[:free]
+	}
+
+	// Copy in at most two parts: up to the end of the buffer, then from its start
+	n := copy(r.buffer[r.head:], items)
+	copy(r.buffer, items[n:])
+	r.head = (r.head + len(items)) % r.size
+	r.count += len(items)
+	return len(items)
+}
+
 // Read removes and returns the oldest item from the buffer
 func (r *RingBuffer[T]) Read() (T, bool) {
 	var zero T
@@ -40,11 +110,43 @@ func (r *RingBuffer[T]) Read() (T, bool) {
 	}
 
 	item := r.buffer[r.tail]
+	r.buffer[r.tail] = zero
 	r.tail = (r.tail + 1) % r.size
 	r.count--
 	return item, true
 }
 
+// ReadSlice removes up to len(dst) of the oldest items from the buffer into dst
+// and returns the number of items read
+func (r *RingBuffer[T]) ReadSlice(dst []T) int {
+	n := r.copyOldest(dst)
+	r.discard(n)
+	return n
+}
+
+// copyOldest copies up to len(dst) of the oldest items into dst without removing them
+func (r *RingBuffer[T]) copyOldest(dst []T) int {
+	if len(dst) > r.count {
+		dst = dst[:r.count]
+	}
+	end := r.tail + len(dst)
+	if end <= r.size {
+		return copy(dst, r.buffer[r.tail:end])
+	}
+	n := copy(dst, r.buffer[r.tail:])
+	return n + copy(dst[n:], r.buffer[:end-r.size])
+}
+
+// discard removes the n oldest items, clearing their slots
+func (r *RingBuffer[T]) discard(n int) {
+	var zero T
+	for i := 0; i < n; i++ {
+		r.buffer[r.tail] = zero
+		r.tail = (r.tail + 1) % r.size
+	}
+	r.count -= n
+}
+
 // Peek returns the oldest item without removing it
 func (r *RingBuffer[T]) Peek() (T, bool) {
 	var zero T
@@ -54,6 +156,66 @@ func (r *RingBuffer[T]) Peek() (T, bool) {
 	return r.buffer[r.tail], true
 }
 
+// PeekN returns up to n of the oldest items, oldest first, without removing them
+func (r *RingBuffer[T]) PeekN(n int) []T {
+	if n > r.count {
+		n = r.count
+	}
+	if n <= 0 {
+		return []T{}
+	}
+	items := make([]T, n)
+	r.copyOldest(items)
+	return items
+}
+
+// At returns the item at index i without removing it. Index 0 is the oldest item;
+// negative indices count from the newest item, so -1 is the newest.
+// It returns false if the index is out of range
+func (r *RingBuffer[T]) At(i int) (T, bool) {
+	if i < 0 {
+		i += r.count
+	}
+	if i < 0 || i >= r.count {
+		var zero T
+		return zero, false
+	}
+	return r.buffer[(r.tail+i)%r.size], true
+}
+
+// ForEach calls fn with each item from oldest to newest without removing them,
+// until fn returns false
+func (r *RingBuffer[T]) ForEach(fn func(item T) bool) {
+	for i := 0; i < r.count; i++ {
+		if !fn(r.buffer[(r.tail+i)%r.size]) {
+			return
+		}
+	}
+}
+
+// Iterator returns an iterator over a snapshot of the items, from oldest to newest
+func (r *RingBuffer[T]) Iterator() iterator.Iterator[T] {
+	return NewIterator(r)
+}
+
+// Resize changes the capacity of the buffer, keeping the items in order.
+// If the new capacity is smaller than Len(), the oldest items are discarded
+func (r *RingBuffer[T]) Resize(newCap int) {
+	if newCap <= 0 {
+		newCap = 1
+	}
+	if r.count > newCap {
+		r.discard(r.count - newCap)
+	}
+
+	buffer := make([]T, newCap)
+	r.copyOldest(buffer)
+	r.buffer = buffer
+	r.size = newCap
+	r.tail = 0
+	r.head = r.count % newCap
+}
+
 // IsFull returns true if the buffer is at capacity
 func (r *RingBuffer[T]) IsFull() bool {
 	return r.count == r.size
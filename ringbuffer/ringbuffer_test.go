@@ -2,6 +2,8 @@ package ringbuffer
 
 import (
 	"testing"
+
+	"github.com/idsulik/go-collections/v3/internal/slices"
 )
 
 func TestRingBuffer(t *testing.T) {
@@ -116,3 +118,278 @@ func TestRingBuffer(t *testing.T) {
 		},
 	)
 }
+
+func TestRingBuffer_Overwrite(t *testing.T) {
+	t.Run(
+		"Overwrite method", func(t *testing.T) {
+			rb := New[int](2)
+			if _, evicted := rb.Overwrite(1); evicted {
+				t.Error("Nothing should be evicted from a buffer with free space")
+			}
+			rb.Overwrite(2)
+
+			item, evicted := rb.Overwrite(3)
+			if !evicted || item != 1 {
+				t.Errorf("Expected to evict 1, got %d, %v", item, evicted)
+			}
+			assertItems(t, rb, []int{2, 3})
+		},
+	)
+
+	t.Run(
+		"Overwrite mode", func(t *testing.T) {
+			rb := New[int](3, WithOverwrite[int]())
+			for i := 1; i <= 5; i++ {
+				if !rb.Write(i) {
+					t.Errorf("Write(%d) should succeed in overwrite mode", i)
+				}
+			}
+			if !rb.IsFull() {
+				t.Error("Buffer should be full")
+			}
+			assertItems(t, rb, []int{3, 4, 5})
+
+			rb.Read()
+			rb.Write(6)
+			rb.Write(7)
+			assertItems(t, rb, []int{5, 6, 7})
+		},
+	)
+}
+
+func TestRingBuffer_WriteSlice(t *testing.T) {
+	tests := []struct {
+		name      string
+		overwrite bool
+		initial   []int
+		items     []int
+		written   int
+		expected  []int
+	}{
+		{"Fits", false, []int{1}, []int{2, 3}, 2, []int{1, 2, 3}},
+		{"Partially fits", false, []int{1, 2}, []int{3, 4, 5}, 2, []int{1, 2, 3, 4}},
+		{"Full", false, []int{1, 2, 3, 4}, []int{5}, 0, []int{1, 2, 3, 4}},
+		{"Overwrite evicts oldest", true, []int{1, 2, 3}, []int{4, 5}, 2, []int{2, 3, 4, 5}},
+		{"Overwrite longer than capacity", true, []int{1}, []int{2, 3, 4, 5, 6, 7}, 6, []int{4, 5, 6, 7}},
+		{"Empty slice", false, []int{1}, nil, 0, []int{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				var opts []Option[int]
+				if tt.overwrite {
+					opts = append(opts, WithOverwrite[int]())
+				}
+				rb := New[int](4, opts...)
+
+				// Move the read position so that writes wrap around the end of the buffer
+				rb.WriteSlice([]int{0, 0, 0})
+				rb.ReadSlice(make([]int, 3))
+
+				rb.WriteSlice(tt.initial)
+				if n := rb.WriteSlice(tt.items); n != tt.written {
+					t.Errorf("Expected %d items written, got %d", tt.written, n)
+				}
+				assertItems(t, rb, tt.expected)
+			},
+		)
+	}
+}
+
+func TestRingBuffer_ReadSlice(t *testing.T) {
+	rb := New[int](4)
+	rb.WriteSlice([]int{1, 2, 3})
+	rb.Read()
+	rb.WriteSlice([]int{4, 5})
+
+	dst := make([]int, 3)
+	if n := rb.ReadSlice(dst); n != 3 || dst[0] != 2 || dst[1] != 3 || dst[2] != 4 {
+		t.Errorf("Expected to read [2 3 4], got %v (%d)", dst[:n], n)
+	}
+	if n := rb.ReadSlice(dst); n != 1 || dst[0] != 5 {
+		t.Errorf("Expected to read [5], got %v", dst[:n])
+	}
+	if n := rb.ReadSlice(dst); n != 0 || !rb.IsEmpty() {
+		t.Errorf("Expected to read nothing from an empty buffer, got %d", n)
+	}
+}
+
+func TestRingBuffer_PeekN(t *testing.T) {
+	rb := New[string](3)
+	rb.WriteSlice([]string{"a", "b", "c"})
+	rb.Read()
+	rb.Write("d")
+
+	tests := []struct {
+		n        int
+		expected []string
+	}{
+		{0, []string{}},
+		{-1, []string{}},
+		{2, []string{"b", "c"}},
+		{5, []string{"b", "c", "d"}},
+	}
+
+	for _, tt := range tests {
+		got := rb.PeekN(tt.n)
+		if !slices.Equal(got, tt.expected) {
+			t.Errorf("PeekN(%d) = %v, want %v", tt.n, got, tt.expected)
+		}
+	}
+	if rb.Len() != 3 {
+		t.Error("PeekN should not remove items")
+	}
+}
+
+func TestRingBuffer_At(t *testing.T) {
+	rb := New[int](3)
+	rb.WriteSlice([]int{1, 2, 3})
+	rb.Read()
+	rb.Write(4)
+
+	tests := []struct {
+		index    int
+		expected int
+		ok       bool
+	}{
+		{0, 2, true},
+		{2, 4, true},
+		{-1, 4, true},
+		{-3, 2, true},
+		{3, 0, false},
+		{-4, 0, false},
+	}
+
+	for _, tt := range tests {
+		if item, ok := rb.At(tt.index); item != tt.expected || ok != tt.ok {
+			t.Errorf("At(%d) = %d, %v, want %d, %v", tt.index, item, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestRingBuffer_ForEach(t *testing.T) {
+	rb := New[int](3)
+	rb.WriteSlice([]int{1, 2, 3})
+	rb.Read()
+	rb.Write(4)
+
+	var visited []int
+	rb.ForEach(
+		func(item int) bool {
+			visited = append(visited, item)
+			return item != 3
+		},
+	)
+	if !slices.Equal(visited, []int{2, 3}) {
+		t.Errorf("Expected to visit [2 3], got %v", visited)
+	}
+	if rb.Len() != 3 {
+		t.Error("ForEach should not remove items")
+	}
+}
+
+func TestRingBuffer_Resize(t *testing.T) {
+	tests := []struct {
+		name     string
+		newCap   int
+		expected []int
+	}{
+		{"Grow", 6, []int{2, 3, 4, 5}},
+		{"Same", 4, []int{2, 3, 4, 5}},
+		{"Shrink discards oldest", 2, []int{4, 5}},
+		{"Invalid capacity", 0, []int{5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				rb := New[int](4)
+				rb.WriteSlice([]int{1, 2, 3, 4})
+				rb.Read()
+				rb.Write(5) // Wrapped around
+
+				rb.Resize(tt.newCap)
+				expectedCap := tt.newCap
+				if expectedCap < 1 {
+					expectedCap = 1
+				}
+				if rb.Cap() != expectedCap {
+					t.Errorf("Expected capacity %d, got %d", expectedCap, rb.Cap())
+				}
+				assertItems(t, rb, tt.expected)
+			},
+		)
+	}
+
+	rb := New[int](2)
+	rb.WriteSlice([]int{1, 2})
+	rb.Resize(3)
+	rb.Write(3)
+	assertItems(t, rb, []int{1, 2, 3})
+}
+
+func TestRingBuffer_ReadClearsSlots(t *testing.T) {
+	rb := New[*int](2)
+	value := 1
+	rb.Write(&value)
+	rb.Read()
+	rb.Write(&value)
+	rb.ReadSlice(make([]*int, 1))
+	for i, p := range rb.buffer {
+		if p != nil {
+			t.Errorf("Expected slot %d to be cleared to release the item", i)
+		}
+	}
+}
+
+// assertItems checks the content of the buffer with At, PeekN and the iterator without draining it,
+// then drains it with Read
+func assertItems(t *testing.T, rb *RingBuffer[int], expected []int) {
+	t.Helper()
+	if rb.Len() != len(expected) {
+		t.Fatalf("Expected length %d, got %d", len(expected), rb.Len())
+	}
+	for i, want := range expected {
+		if item, ok := rb.At(i); !ok || item != want {
+			t.Errorf("At(%d) = %d, want %d", i, item, want)
+		}
+	}
+	if got := rb.PeekN(len(expected)); !slices.Equal(got, expected) {
+		t.Errorf("PeekN = %v, want %v", got, expected)
+	}
+
+	var iterated []int
+	for it := rb.Iterator(); it.HasNext(); {
+		item, _ := it.Next()
+		iterated = append(iterated, item)
+	}
+	if len(expected) > 0 && !slices.Equal(iterated, expected) {
+		t.Errorf("Iterator yielded %v, want %v", iterated, expected)
+	}
+}
+
+func BenchmarkRingBuffer(b *testing.B) {
+	b.Run(
+		"WriteRead", func(b *testing.B) {
+			rb := New[int](1024)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				rb.Write(i)
+				rb.Read()
+			}
+		},
+	)
+
+	b.Run(
+		"WriteSliceReadSlice", func(b *testing.B) {
+			rb := New[int](1024)
+			chunk := make([]int, 100)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				rb.WriteSlice(chunk)
+				rb.ReadSlice(chunk)
+			}
+		},
+	)
+}
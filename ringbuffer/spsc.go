@@ -0,0 +1,96 @@
+package ringbuffer
+
+import (
+	"sync/atomic"
+)
+
+// cacheLineSize is used to pad fields written by different goroutines onto separate cache lines
+const cacheLineSize = 64
+
+// SPSC is a lock-free bounded ring buffer for exactly one producer and one consumer goroutine.
+// TryWrite must only be called by the producer and TryRead only by the consumer.
+type SPSC[T any] struct {
+	// The 64-bit counters come first so that they are aligned for atomic access on 32-bit platforms
+	tail       uint64 // next write position, owned by the producer
+	cachedHead uint64 // producer's last seen head
+	_          [cacheLineSize - 16]byte
+	head       uint64 // next read position, owned by the consumer
+	cachedTail uint64 // consumer's last seen tail
+	_          [cacheLineSize - 16]byte
+	buffer     []T
+	mask       uint64
+}
+
+// NewSPSC creates a new SPSC ring buffer. The capacity is rounded up to a power of two.
+func NewSPSC[T any](capacity int) *SPSC[T] {
+	size := roundUpPow2(capacity)
+	return &SPSC[T]{
+		buffer: make([]T, size),
+		mask:   size - 1,
+	}
+}
+
+// TryWrite adds an item to the buffer without blocking. It returns false if the buffer is full.
+func (r *SPSC[T]) TryWrite(item T) bool {
+	tail := r.tail
+	if tail-r.cachedHead == uint64(len(r.buffer)) {
+		r.cachedHead = atomic.LoadUint64(&r.head)
+		if tail-r.cachedHead == uint64(len(r.buffer)) {
+			return false
+		}
+	}
+	r.buffer[tail&r.mask] = item
+	atomic.StoreUint64(&r.tail, tail+1)
+	return true
+}
+
+// TryRead removes and returns the oldest item without blocking. It returns false if the buffer is empty.
+func (r *SPSC[T]) TryRead() (T, bool) {
+	var zero T
+	head := r.head
+	if head == r.cachedTail {
+		r.cachedTail = atomic.LoadUint64(&r.tail)
+		if head == r.cachedTail {
+			return zero, false
+		}
+	}
+	slot := &r.buffer[head&r.mask]
+	item := *slot
+	*slot = zero
+	atomic.StoreUint64(&r.head, head+1)
+	return item, true
+}
+
+// Len returns the number of items in the buffer. The result is only a snapshot
+// while the producer or consumer is running.
+func (r *SPSC[T]) Len() int {
+	return snapshotLen(&r.head, &r.tail, len(r.buffer))
+}
+
+// Cap returns the capacity of the buffer
+func (r *SPSC[T]) Cap() int {
+	return len(r.buffer)
+}
+
+// snapshotLen returns tail-head clamped to [0, capacity]. Head is loaded before tail, so concurrent
+// writes and reads can only make the difference too large, never negative.
+func snapshotLen(head, tail *uint64, capacity int) int {
+	h := atomic.LoadUint64(head)
+	t := atomic.LoadUint64(tail)
+	if t <= h {
+		return 0
+	}
+	if n := t - h; n < uint64(capacity) {
+		return int(n)
+	}
+	return capacity
+}
+
+// roundUpPow2 returns the smallest power of two that is at least n, and at least 1
+func roundUpPow2(n int) uint64 {
+	size := uint64(1)
+	for size < uint64(n) && n > 0 {
+		size <<= 1
+	}
+	return size
+}
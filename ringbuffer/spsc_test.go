@@ -0,0 +1,147 @@
+package ringbuffer
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestSPSC(t *testing.T) {
+	t.Run(
+		"Capacity is rounded up to a power of two", func(t *testing.T) {
+			tests := []struct {
+				capacity int
+				expected int
+			}{
+				{0, 1},
+				{1, 1},
+				{3, 4},
+				{8, 8},
+				{9, 16},
+			}
+			for _, tt := range tests {
+				if got := NewSPSC[int](tt.capacity).Cap(); got != tt.expected {
+					t.Errorf("NewSPSC(%d).Cap() = %d, want %d", tt.capacity, got, tt.expected)
+				}
+			}
+		},
+	)
+
+	t.Run(
+		"Full and empty", func(t *testing.T) {
+			r := NewSPSC[int](4)
+			if _, ok := r.TryRead(); ok {
+				t.Error("TryRead should fail on an empty buffer")
+			}
+			for i := 0; i < 4; i++ {
+				if !r.TryWrite(i) {
+					t.Fatalf("TryWrite(%d) should succeed", i)
+				}
+			}
+			if r.TryWrite(4) {
+				t.Error("TryWrite should fail on a full buffer")
+			}
+			if r.Len() != 4 {
+				t.Errorf("Expected length 4, got %d", r.Len())
+			}
+		},
+	)
+
+	t.Run(
+		"FIFO order across wrap around", func(t *testing.T) {
+			r := NewSPSC[int](4)
+			next := 0
+			for i := 0; i < 20; i++ {
+				r.TryWrite(i)
+				if i%2 == 1 {
+					for j := 0; j < 2; j++ {
+						item, ok := r.TryRead()
+						if !ok || item != next {
+							t.Fatalf("TryRead() = %d, %v; want %d, true", item, ok, next)
+						}
+						next++
+					}
+				}
+			}
+			if !r.TryWrite(20) || r.Len() != 1 {
+				t.Errorf("Expected one item after draining, got %d", r.Len())
+			}
+		},
+	)
+
+	t.Run(
+		"Read clears the slot", func(t *testing.T) {
+			r := NewSPSC[*int](2)
+			r.TryWrite(new(int))
+			r.TryRead()
+			if r.buffer[0] != nil {
+				t.Error("TryRead should clear the slot it read from")
+			}
+		},
+	)
+}
+
+func TestSPSC_Concurrent(t *testing.T) {
+	const n = 100000
+	r := NewSPSC[int](64)
+
+	go func() {
+		for i := 0; i < n; i++ {
+			for !r.TryWrite(i) {
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	for expected := 0; expected < n; {
+		item, ok := r.TryRead()
+		if !ok {
+			runtime.Gosched()
+			continue
+		}
+		if item != expected {
+			t.Fatalf("TryRead() = %d, want %d", item, expected)
+		}
+		expected++
+	}
+}
+
+func BenchmarkSPSC(b *testing.B) {
+	b.Run(
+		"SPSC", func(b *testing.B) {
+			r := NewSPSC[int](1024)
+			b.ReportAllocs()
+			done := make(chan struct{})
+			go func() {
+				for i := 0; i < b.N; i++ {
+					for !r.TryWrite(i) {
+						runtime.Gosched()
+					}
+				}
+				close(done)
+			}()
+			for i := 0; i < b.N; {
+				if _, ok := r.TryRead(); ok {
+					i++
+				} else {
+					runtime.Gosched()
+				}
+			}
+			<-done
+		},
+	)
+
+	b.Run(
+		"Channel", func(b *testing.B) {
+			ch := make(chan int, 1024)
+			b.ReportAllocs()
+			go func() {
+				for i := 0; i < b.N; i++ {
+					ch <- i
+				}
+			}()
+			for i := 0; i < b.N; i++ {
+				<-ch
+			}
+		},
+	)
+}
@@ -0,0 +1,103 @@
+package xorfilter
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/idsulik/go-collections/v3/bloomfilter"
+	"github.com/idsulik/go-collections/v3/internal/binenc"
+)
+
+// The binary encoding is a 32-byte header followed by the fingerprints:
+//
+//	magic "XF" | version | 1 reserved byte | segmentLength | segmentCount | seed | count | 4 reserved bytes
+//
+// where segmentLength and segmentCount are little-endian uint32, seed is a little-endian uint64
+// and count is a little-endian uint32.
+const (
+	encodingVersion = 1
+	headerSize      = 32
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The hasher is not encoded: the decoding side must use the same one.
+func (f *BinaryFuse8[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, headerSize+len(f.fingerprints))
+	data[0], data[1], data[2] = 'X', 'F', encodingVersion
+	binary.LittleEndian.PutUint32(data[4:], f.segmentLength)
+	binary.LittleEndian.PutUint32(data[8:], f.segmentCount)
+	binary.LittleEndian.PutUint64(data[12:], f.seed)
+	binary.LittleEndian.PutUint32(data[20:], uint32(f.count))
+	copy(data[headerSize:], f.fingerprints)
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It replaces the contents of the filter and keeps its hasher, using the default one if none is set.
+func (f *BinaryFuse8[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize {
+		return fmt.Errorf("%w: data too short", ErrInvalidEncoding)
+	}
+	decoded, err := parseHeader[T](data)
+	if err != nil {
+		return err
+	}
+	if uint64(len(data)-headerSize) != decoded.arrayLength() {
+		return fmt.Errorf("%w: expected %d fingerprints, got %d", ErrInvalidEncoding, decoded.arrayLength(), len(data)-headerSize)
+	}
+	decoded.fingerprints = append([]uint8(nil), data[headerSize:]...)
+
+	decoded.hash = f.hash
+	if decoded.hash == nil {
+		decoded.hash = bloomfilter.DefaultHasher[T]()
+	}
+	*f = *decoded
+	return nil
+}
+
+// WriteTo implements io.WriterTo, writing the binary encoding of the filter to w.
+func (f *BinaryFuse8[T]) WriteTo(w io.Writer) (int64, error) {
+	return binenc.WriteTo(w, f)
+}
+
+// ReadFrom implements io.ReaderFrom, reading a filter written by WriteTo from r.
+// It reads exactly the bytes of one encoded filter.
+func (f *BinaryFuse8[T]) ReadFrom(r io.Reader) (int64, error) {
+	return binenc.ReadFrom(
+		r, f, headerSize, func(data []byte) (int64, error) {
+			decoded, err := parseHeader[T](data)
+			if err != nil {
+				return 0, err
+			}
+			return headerSize + int64(decoded.arrayLength()), nil
+		},
+	)
+}
+
+// parseHeader returns a filter without fingerprints with the parameters of the header.
+func parseHeader[T any](data []byte) (*BinaryFuse8[T], error) {
+	if data[0] != 'X' || data[1] != 'F' {
+		return nil, fmt.Errorf("%w: bad magic", ErrInvalidEncoding)
+	}
+	if data[2] != encodingVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, data[2])
+	}
+	segmentLength := binary.LittleEndian.Uint32(data[4:])
+	segmentCount := binary.LittleEndian.Uint32(data[8:])
+	if segmentLength == 0 || segmentLength&(segmentLength-1) != 0 || segmentLength > maxSegmentLength ||
+		segmentCount == 0 || uint64(segmentCount)*uint64(segmentLength) > 1<<32-1 {
+		return nil, fmt.Errorf("%w: invalid segments %dx%d", ErrInvalidEncoding, segmentCount, segmentLength)
+	}
+
+	f := &BinaryFuse8[T]{
+		seed:  binary.LittleEndian.Uint64(data[12:]),
+		count: int(binary.LittleEndian.Uint32(data[20:])),
+	}
+	f.setSegments(segmentLength, segmentCount)
+	return f, nil
+}
+
+func (f *BinaryFuse8[T]) arrayLength() uint64 {
+	return (uint64(f.segmentCount) + arity - 1) * uint64(f.segmentLength)
+}
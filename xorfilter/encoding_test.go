@@ -0,0 +1,97 @@
+package xorfilter
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestBinaryFuse8_MarshalBinary(t *testing.T) {
+	for _, size := range []int{0, 1, 1000} {
+		keys := make([]int, size)
+		for i := range keys {
+			keys[i] = i * 7
+		}
+		f, _ := New(keys)
+
+		data, err := f.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var decoded BinaryFuse8[int]
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if decoded.Len() != size || decoded.SizeInBytes() != f.SizeInBytes() {
+			t.Errorf("Expected %d keys in %d bytes, got %d in %d", size, f.SizeInBytes(), decoded.Len(), decoded.SizeInBytes())
+		}
+		for i := 0; i < 7*size+7; i++ {
+			if decoded.Contains(i) != f.Contains(i) {
+				t.Fatalf("Contains(%d) differs after decoding", i)
+			}
+		}
+	}
+}
+
+func TestBinaryFuse8_UnmarshalBinaryErrors(t *testing.T) {
+	f, _ := New([]int{1, 2, 3})
+	valid, _ := f.MarshalBinary()
+
+	corrupt := func(fn func(data []byte)) []byte {
+		data := append([]byte(nil), valid...)
+		fn(data)
+		return data
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"Empty", nil},
+		{"Bad magic", corrupt(func(d []byte) { d[0] = 'Y' })},
+		{"Unknown version", corrupt(func(d []byte) { d[2] = 0 })},
+		{"Segment length not a power of two", corrupt(func(d []byte) { d[4] = 3 })},
+		{"Zero segments", corrupt(func(d []byte) { d[8] = 0 })},
+		{"Truncated fingerprints", valid[:len(valid)-1]},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				var decoded BinaryFuse8[int]
+				if err := decoded.UnmarshalBinary(tt.data); !errors.Is(err, ErrInvalidEncoding) {
+					t.Errorf("Expected ErrInvalidEncoding, got %v", err)
+				}
+			},
+		)
+	}
+}
+
+func TestBinaryFuse8_WriteToReadFrom(t *testing.T) {
+	f, _ := New([]string{"apple", "banana", "cherry"})
+
+	var buf bytes.Buffer
+	written, err := f.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	buf.WriteString("trailer")
+
+	var decoded BinaryFuse8[string]
+	read, err := decoded.ReadFrom(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if read != written || buf.String() != "trailer" {
+		t.Errorf("Expected to read %d bytes, read %d and left %q", written, read, buf.String())
+	}
+	if !decoded.Contains("banana") {
+		t.Error("Decoded filter should contain banana")
+	}
+
+	data, _ := f.MarshalBinary()
+	if _, err := decoded.ReadFrom(bytes.NewReader(data[:len(data)-1])); err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
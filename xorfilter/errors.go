@@ -0,0 +1,12 @@
+package xorfilter
+
+import "errors"
+
+var (
+	// ErrConstructionFailed is returned when no filter could be built from the keys, which only
+	// happens with a hasher that maps many distinct keys to the same hash.
+	ErrConstructionFailed = errors.New("xorfilter: construction failed")
+
+	// ErrInvalidEncoding is returned when decoding data that is not a valid encoded filter.
+	ErrInvalidEncoding = errors.New("xorfilter: invalid encoding")
+)
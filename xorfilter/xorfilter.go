@@ -0,0 +1,265 @@
+package xorfilter
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/idsulik/go-collections/v3/bloomfilter"
+)
+
+const (
+	arity            = 3
+	maxSegmentLength = 1 << 18
+	maxAttempts      = 100
+)
+
+// BinaryFuse8 is a static approximate-membership filter built once from a set of keys, following
+// "Binary Fuse Filters: Fast and Smaller Than Xor Filters" (Graf and Lemire, 2022). It stores an
+// 8-bit fingerprint per slot and, for large sets, uses about 9 bits per key for a false positive
+// rate of 1/256, compared to about 11.5 bits per key for a Bloom filter with the same rate. Keys cannot be added
+// or removed after construction.
+type BinaryFuse8[T any] struct {
+	seed               uint64
+	segmentLength      uint32
+	segmentLengthMask  uint32
+	segmentCount       uint32
+	segmentCountLength uint32
+	fingerprints       []uint8
+	count              int
+	hash               bloomfilter.Hasher[T]
+}
+
+// Option is a function that configures a BinaryFuse8.
+type Option[T any] func(*BinaryFuse8[T])
+
+// WithHasher sets the function used to hash items. Only the first half of the 128-bit hash is used.
+func WithHasher[T any](hasher bloomfilter.Hasher[T]) Option[T] {
+	return func(f *BinaryFuse8[T]) {
+		f.hash = hasher
+	}
+}
+
+// WithEncoder hashes items from the bytes produced by enc.
+func WithEncoder[T any](enc bloomfilter.Encoder[T]) Option[T] {
+	return func(f *BinaryFuse8[T]) {
+		f.hash = bloomfilter.EncoderHasher(enc)
+	}
+}
+
+// New builds a filter containing the keys. Duplicate keys are allowed.
+// It returns ErrConstructionFailed if the keys cannot be placed, which does not happen
+// with a well-distributed hasher.
+func New[T any](keys []T, opts ...Option[T]) (*BinaryFuse8[T], error) {
+	f := &BinaryFuse8[T]{hash: bloomfilter.DefaultHasher[T]()}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	hashes := make([]uint64, len(keys))
+	for i, key := range keys {
+		hashes[i], _ = f.hash(key)
+	}
+	f.initParameters(uint32(len(hashes)))
+	if len(hashes) == 0 {
+		return f, nil
+	}
+
+	seed := uint64(0x726b2b9d438b9d4d)
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		f.seed = splitmix64(&seed)
+		if f.populate(hashes) {
+			f.count = len(hashes)
+			return f, nil
+		}
+		// Duplicate hashes can never be peeled, so remove them before retrying
+		if attempt == 0 {
+			hashes = unique(hashes)
+		}
+	}
+	return nil, ErrConstructionFailed
+}
+
+type uint64Slice []uint64
+
+func (s uint64Slice) Len() int           { return len(s) }
+func (s uint64Slice) Less(i, j int) bool { return s[i] < s[j] }
+func (s uint64Slice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+func unique(hashes []uint64) []uint64 {
+	sort.Sort(uint64Slice(hashes))
+	result := hashes[:0]
+	for i, h := range hashes {
+		if i == 0 || h != hashes[i-1] {
+			result = append(result, h)
+		}
+	}
+	return result
+}
+
+// initParameters sizes the segments for the number of keys, using the parameters of the reference implementation.
+func (f *BinaryFuse8[T]) initParameters(size uint32) {
+	segmentLength := uint32(4)
+	if size > 1 {
+		segmentLength = 1 << int(math.Floor(math.Log(float64(size))/math.Log(3.33)+2.25))
+	}
+	if segmentLength > maxSegmentLength {
+		segmentLength = maxSegmentLength
+	}
+
+	capacity := uint32(0)
+	if size > 1 {
+		sizeFactor := math.Max(1.125, 0.875+0.25*math.Log(1000000)/math.Log(float64(size)))
+		capacity = uint32(math.Round(float64(size) * sizeFactor))
+	}
+
+	segmentCount := (capacity + segmentLength - 1) / segmentLength
+	if segmentCount <= arity-1 {
+		segmentCount = 1
+	} else {
+		segmentCount -= arity - 1
+	}
+	f.setSegments(segmentLength, segmentCount)
+	f.fingerprints = make([]uint8, f.arrayLength())
+}
+
+func (f *BinaryFuse8[T]) setSegments(segmentLength, segmentCount uint32) {
+	f.segmentLength = segmentLength
+	f.segmentLengthMask = segmentLength - 1
+	f.segmentCount = segmentCount
+	f.segmentCountLength = segmentCount * segmentLength
+}
+
+// positions returns the three slots of a mixed hash, one in each of three consecutive segments.
+func (f *BinaryFuse8[T]) positions(h uint64) [arity]uint32 {
+	hi, _ := bits.Mul64(h, uint64(f.segmentCountLength))
+	h0 := uint32(hi)
+	h1 := h0 + f.segmentLength
+	h2 := h1 + f.segmentLength
+	h1 ^= uint32(h>>18) & f.segmentLengthMask
+	h2 ^= uint32(h) & f.segmentLengthMask
+	return [arity]uint32{h0, h1, h2}
+}
+
+func fingerprint(h uint64) uint8 {
+	return uint8(h ^ h>>32)
+}
+
+// mix derives the hash used by the filter from an item hash and the seed.
+func (f *BinaryFuse8[T]) mix(h uint64) uint64 {
+	h += f.seed
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+func splitmix64(state *uint64) uint64 {
+	*state += 0x9e3779b97f4a7c15
+	z := *state
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return z ^ z>>31
+}
+
+// populate assigns the fingerprints by peeling the 3-hypergraph of the keys: slots used by a single
+// remaining key are removed one after another, then fingerprints are assigned in reverse order so
+// that the XOR of the three slots of every key equals its fingerprint. It returns false if the
+// hypergraph has a non-empty core, in which case another seed must be tried.
+func (f *BinaryFuse8[T]) populate(hashes []uint64) bool {
+	n := len(f.fingerprints)
+	counts := make([]uint32, n) // Number of keys using the slot
+	xorHashes := make([]uint64, n)
+	for i := range f.fingerprints {
+		f.fingerprints[i] = 0
+	}
+
+	for _, h := range hashes {
+		h = f.mix(h)
+		for _, slot := range f.positions(h) {
+			counts[slot]++
+			xorHashes[slot] ^= h
+		}
+	}
+
+	queue := make([]uint32, 0, n)
+	for slot, count := range counts {
+		if count == 1 {
+			queue = append(queue, uint32(slot))
+		}
+	}
+
+	type peeled struct {
+		hash uint64
+		slot uint32
+	}
+	stack := make([]peeled, 0, len(hashes))
+	for len(queue) > 0 {
+		slot := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		if counts[slot] != 1 {
+			continue
+		}
+		h := xorHashes[slot]
+		stack = append(stack, peeled{h, slot})
+		for _, other := range f.positions(h) {
+			counts[other]--
+			xorHashes[other] ^= h
+			if counts[other] == 1 {
+				queue = append(queue, other)
+			}
+		}
+	}
+	if len(stack) != len(hashes) {
+		return false
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		h, slot := stack[i].hash, stack[i].slot
+		fp := fingerprint(h)
+		for _, other := range f.positions(h) {
+			if other != slot {
+				fp ^= f.fingerprints[other]
+			}
+		}
+		f.fingerprints[slot] = fp
+	}
+	return true
+}
+
+// Contains tests whether an item might be one of the keys. It never returns false for a key,
+// and returns true for other items with a probability of about 1/256.
+func (f *BinaryFuse8[T]) Contains(item T) bool {
+	if f.count == 0 {
+		return false
+	}
+	h, _ := f.hash(item)
+	h = f.mix(h)
+	p := f.positions(h)
+	return fingerprint(h)^f.fingerprints[p[0]]^f.fingerprints[p[1]]^f.fingerprints[p[2]] == 0
+}
+
+// Len returns the number of distinct keys the filter was built from.
+func (f *BinaryFuse8[T]) Len() int {
+	return f.count
+}
+
+// IsEmpty returns true if the filter was built from no keys.
+func (f *BinaryFuse8[T]) IsEmpty() bool {
+	return f.count == 0
+}
+
+// SizeInBytes returns the size of the fingerprint array.
+func (f *BinaryFuse8[T]) SizeInBytes() int {
+	return len(f.fingerprints)
+}
+
+// BitsPerItem returns the number of fingerprint bits used per key.
+func (f *BinaryFuse8[T]) BitsPerItem() float64 {
+	if f.count == 0 {
+		return 0
+	}
+	return float64(8*len(f.fingerprints)) / float64(f.count)
+}
@@ -0,0 +1,148 @@
+package xorfilter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/idsulik/go-collections/v3/bloomfilter"
+)
+
+func TestBinaryFuse8(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		maxBits float64
+	}{
+		{"Empty", 0, 0},
+		{"Single key", 1, 0},
+		{"Two keys", 2, 0},
+		{"Small", 100, 0},
+		{"Medium", 10000, 10.5},
+		{"Large", 1000000, 9.1},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				keys := make([]uint64, tt.size)
+				for i := range keys {
+					keys[i] = uint64(i) * 0x9e3779b97f4a7c15
+				}
+				f, err := New(keys)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if f.Len() != tt.size || f.IsEmpty() != (tt.size == 0) {
+					t.Errorf("Expected length %d, got %d", tt.size, f.Len())
+				}
+				for _, key := range keys {
+					if !f.Contains(key) {
+						t.Fatalf("Should contain %d", key)
+					}
+				}
+
+				if bits := f.BitsPerItem(); tt.maxBits > 0 && bits > tt.maxBits {
+					t.Errorf("Expected at most %.1f bits per key, got %.2f", tt.maxBits, bits)
+				}
+				if tt.size >= 1000 {
+					falsePositives := 0
+					trials := 200000
+					for i := 0; i < trials; i++ {
+						if f.Contains(uint64(i)*0x9e3779b97f4a7c15 + 1) {
+							falsePositives++
+						}
+					}
+					if rate := float64(falsePositives) / float64(trials); rate > 0.006 {
+						t.Errorf("False positive rate %f is too high", rate)
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestBinaryFuse8_Duplicates(t *testing.T) {
+	keys := []string{"a", "b", "a", "c", "b", "a"}
+	f, err := New(keys)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if f.Len() != 3 {
+		t.Errorf("Expected 3 distinct keys, got %d", f.Len())
+	}
+	for _, key := range keys {
+		if !f.Contains(key) {
+			t.Errorf("Should contain %s", key)
+		}
+	}
+}
+
+func TestBinaryFuse8_Options(t *testing.T) {
+	type Point struct {
+		X, Y int
+	}
+	points := []Point{{1, 2}, {3, 4}, {5, 6}}
+	f, err := New(
+		points, WithEncoder(
+			func(dst []byte, p Point) []byte {
+				return append(dst, byte(p.X), byte(p.Y))
+			},
+		),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, p := range points {
+		if !f.Contains(p) {
+			t.Errorf("Should contain %v", p)
+		}
+	}
+
+	// A hasher mapping every key to the same hash still works, as duplicates are removed
+	constant := func(string) (uint64, uint64) { return 42, 42 }
+	f2, err := New([]string{"x", "y"}, WithHasher[string](constant))
+	if err != nil || f2.Len() != 1 || !f2.Contains("z") {
+		t.Errorf("Expected a single-key filter, got %v, %v", f2, err)
+	}
+}
+
+func TestBinaryFuse8_SmallerThanBloomFilter(t *testing.T) {
+	const n = 100000
+	keys := make([]int, n)
+	for i := range keys {
+		keys[i] = i
+	}
+	f, _ := New(keys)
+	bf := bloomfilter.NewBloomFilter[int](n, 1.0/256)
+	if uint(8*f.SizeInBytes()) >= bf.BitSize() {
+		t.Errorf("Expected %d bits to be less than the %d bits of a Bloom filter", 8*f.SizeInBytes(), bf.BitSize())
+	}
+}
+
+func BenchmarkBinaryFuse8(b *testing.B) {
+	const n = 1 << 16
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("item%d", i)
+	}
+
+	b.Run(
+		"New", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = New(keys)
+			}
+		},
+	)
+
+	b.Run(
+		"Contains", func(b *testing.B) {
+			f, _ := New(keys[:n/2])
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				f.Contains(keys[i%n])
+			}
+		},
+	)
+}